@@ -10,6 +10,7 @@ const activityBasePath = "/activity"
 
 type ActivityService interface {
 	List(ctx context.Context, options *ActivityOptions) (*ActivityRoot, *Response, error)
+	ListAll(ctx context.Context, options *ActivityOptions) *Iterator[ActivityData]
 }
 
 type activityService struct {
@@ -71,10 +72,28 @@ func (s *activityService) List(ctx context.Context, options *ActivityOptions) (*
 	}
 
 	root := new(ActivityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Activity.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
 
 	return root, res, nil
 }
+
+func (s *activityService) ListAll(ctx context.Context, options *ActivityOptions) *Iterator[ActivityData] {
+	opts := ActivityOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]ActivityData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
@@ -80,7 +80,7 @@ func (s *analyticsService) GetActivityByDate(ctx context.Context, options *Analy
 	}
 
 	root := new(AnalyticsActivityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Analytics.GetActivityByDate", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -97,7 +97,7 @@ func (s *analyticsService) GetOpensByCountry(ctx context.Context, options *Analy
 	}
 
 	root := new(OpensRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Analytics.GetOpensByCountry", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -114,7 +114,7 @@ func (s *analyticsService) GetOpensByUserAgent(ctx context.Context, options *Ana
 	}
 
 	root := new(OpensRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Analytics.GetOpensByUserAgent", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -131,7 +131,7 @@ func (s *analyticsService) GetOpensByReadingEnvironment(ctx context.Context, opt
 	}
 
 	root := new(OpensRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Analytics.GetOpensByReadingEnvironment", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -29,7 +29,7 @@ func (s *apiQuotaService) Get(ctx context.Context) (*ApiQuotaRoot, *Response, er
 	}
 
 	root := new(ApiQuotaRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "ApiQuota.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -0,0 +1,170 @@
+package mailersend
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrForbiddenAttachment - the file type of the attachment is not accepted by the API
+	ErrForbiddenAttachment = errors.New("mailersend: attachment file type is not allowed")
+	// ErrMessageTooLarge - the attachments exceed the 25MB message limit
+	ErrMessageTooLarge = errors.New("mailersend: attachments exceed the 25MB message limit")
+	// ErrNotAnImage - an inline image is not an image
+	ErrNotAnImage = errors.New("mailersend: inline image is not an image")
+)
+
+// preferredExtensions are used instead of the first extension known to the mime package.
+var preferredExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"text/plain": ".txt",
+	"text/html":  ".html",
+}
+
+// NewAttachmentFromFile - reads and encodes the file at path as an attachment
+func NewAttachmentFromFile(path string) (Attachment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Attachment{}, err
+	}
+	defer f.Close()
+
+	return NewAttachmentFromReader(filepath.Base(path), f)
+}
+
+// NewAttachmentFromReader - reads and encodes r as an attachment named name.
+// The content is encoded while it is read, and reading stops once it exceeds the message limit.
+// When name has no extension, one matching the detected content type is added.
+func NewAttachmentFromReader(name string, r io.Reader) (Attachment, error) {
+	if forbiddenAttachment(name) {
+		return Attachment{}, fmt.Errorf("%w: %s", ErrForbiddenAttachment, name)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return Attachment{}, err
+	}
+	head = head[:n]
+
+	var content strings.Builder
+	encoder := base64.NewEncoder(base64.StdEncoding, &content)
+
+	size, err := io.Copy(encoder, io.LimitReader(io.MultiReader(bytes.NewReader(head), r), MaxMessageSize+1))
+	if err != nil {
+		return Attachment{}, err
+	}
+	if size > MaxMessageSize {
+		return Attachment{}, ErrMessageTooLarge
+	}
+	if err := encoder.Close(); err != nil {
+		return Attachment{}, err
+	}
+
+	contentType := detectContentType(name, head)
+	if filepath.Ext(name) == "" {
+		name += extensionByType(contentType)
+		if forbiddenAttachment(name) {
+			return Attachment{}, fmt.Errorf("%w: %s", ErrForbiddenAttachment, name)
+		}
+	}
+
+	return Attachment{
+		Content:     content.String(),
+		Filename:    name,
+		Disposition: DispositionAttachment,
+		ContentType: contentType,
+	}, nil
+}
+
+// AddInlineImage - Add the image at path as an inline attachment, referenced in the html as cid:<cid>
+func (m *Message) AddInlineImage(cid string, path string) error {
+	attachment, err := NewAttachmentFromFile(path)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(attachment.ContentType, "image/") {
+		return fmt.Errorf("%w: %s", ErrNotAnImage, path)
+	}
+	if m.AttachmentsSize()+attachment.Size() > MaxMessageSize {
+		return ErrMessageTooLarge
+	}
+
+	attachment.ID = cid
+	attachment.Disposition = DispositionInline
+	m.AddAttachment(attachment)
+
+	return nil
+}
+
+// Size - returns the decoded size of the attachment content, which may be wrapped over several lines
+func (a Attachment) Size() int64 {
+	length, padding := 0, 0
+	for i := 0; i < len(a.Content); i++ {
+		switch a.Content[i] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '=':
+			padding++
+		default:
+			padding = 0
+		}
+		length++
+	}
+
+	return int64(base64.RawStdEncoding.DecodedLen(length - padding))
+}
+
+// AttachmentsSize - returns the total decoded size of the attachments
+func (m *Message) AttachmentsSize() int64 {
+	var size int64
+	for _, attachment := range m.Attachments {
+		size += attachment.Size()
+	}
+
+	return size
+}
+
+// checkMessageSize rejects messages whose attachments exceed the message limit before they are uploaded.
+func checkMessageSize(messages ...*Message) error {
+	for _, message := range messages {
+		if message != nil && message.AttachmentsSize() > MaxMessageSize {
+			return ErrMessageTooLarge
+		}
+	}
+
+	return nil
+}
+
+func detectContentType(name string, head []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType
+	}
+
+	return http.DetectContentType(head)
+}
+
+func extensionByType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	if ext, ok := preferredExtensions[mediaType]; ok {
+		return ext
+	}
+
+	exts, err := mime.ExtensionsByType(mediaType)
+	if err != nil || len(exts) == 0 {
+		return ""
+	}
+
+	return exts[0]
+}
@@ -0,0 +1,109 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name string, content []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, content, 0o600))
+
+	return path
+}
+
+func pngImage(t *testing.T) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+
+	return buf.Bytes()
+}
+
+func TestNewAttachmentFromFile(t *testing.T) {
+	path := writeFile(t, "report.csv", []byte("id,email\n1,john@client.com\n"))
+
+	attachment, err := mailersend.NewAttachmentFromFile(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "report.csv", attachment.Filename)
+	assert.Equal(t, mailersend.DispositionAttachment, attachment.Disposition)
+	assert.Equal(t, "text/csv; charset=utf-8", attachment.ContentType)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("id,email\n1,john@client.com\n")), attachment.Content)
+	assert.Equal(t, int64(27), attachment.Size())
+}
+
+func TestAttachmentSizeIgnoresLineBreaks(t *testing.T) {
+	data := bytes.Repeat([]byte("attachment content "), 100)
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var wrapped strings.Builder
+	for len(encoded) > 76 {
+		wrapped.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	wrapped.WriteString(encoded + "\n")
+
+	attachment := mailersend.Attachment{Filename: "notes.txt", Content: wrapped.String()}
+
+	assert.Equal(t, int64(len(data)), attachment.Size())
+}
+
+func TestNewAttachmentFromReaderDetectsType(t *testing.T) {
+	attachment, err := mailersend.NewAttachmentFromReader("logo", bytes.NewReader(pngImage(t)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", attachment.ContentType)
+	assert.Equal(t, "logo.png", attachment.Filename)
+}
+
+func TestNewAttachmentRejectsForbiddenAndLargeFiles(t *testing.T) {
+	_, err := mailersend.NewAttachmentFromReader("setup.EXE", strings.NewReader("MZ"))
+	assert.True(t, errors.Is(err, mailersend.ErrForbiddenAttachment))
+
+	large := bytes.NewReader(make([]byte, mailersend.MaxMessageSize+1))
+	_, err = mailersend.NewAttachmentFromReader("large.bin", large)
+	assert.True(t, errors.Is(err, mailersend.ErrMessageTooLarge))
+}
+
+func TestAddInlineImage(t *testing.T) {
+	message := basicEmailNew()
+
+	err := message.AddInlineImage("logo", writeFile(t, "logo.png", pngImage(t)))
+	assert.NoError(t, err)
+	assert.Equal(t, "logo", message.Attachments[0].ID)
+	assert.Equal(t, mailersend.DispositionInline, message.Attachments[0].Disposition)
+
+	err = message.AddInlineImage("notes", writeFile(t, "notes.txt", []byte("notes")))
+	assert.True(t, errors.Is(err, mailersend.ErrNotAnImage))
+	assert.Len(t, message.Attachments, 1)
+}
+
+func TestSendRejectsLargeMessages(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		t.Fatal("request should not be sent")
+		return nil
+	}))
+
+	chunk := base64.StdEncoding.EncodeToString(make([]byte, 15*1024*1024))
+
+	message := basicEmailNew()
+	message.AddAttachment(mailersend.Attachment{Filename: "a.bin", Content: chunk})
+	message.AddAttachment(mailersend.Attachment{Filename: "b.bin", Content: chunk})
+
+	_, err := ms.Email.Send(context.TODO(), message)
+
+	assert.True(t, errors.Is(err, mailersend.ErrMessageTooLarge))
+}
@@ -1,17 +1,37 @@
 package mailersend
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 const bulkEmailBasePath = "/bulk-email"
 
+// MaxBulkEmailMessages - the maximum number of messages the API accepts in one bulk email request
+const MaxBulkEmailMessages = 500
+
+const (
+	BulkEmailStateQueued     = "queued"
+	BulkEmailStateProcessing = "processing"
+	BulkEmailStateCompleted  = "completed"
+	BulkEmailStateFailed     = "failed"
+)
+
+const maxBulkEmailPollInterval = 30 * time.Second
+
 type BulkEmailService interface {
 	Send(ctx context.Context, message []*Message) (*BulkEmailResponse, *Response, error)
 	Status(ctx context.Context, bulkEmailID string) (*BulkEmailRoot, *Response, error)
+	SendChunked(ctx context.Context, messages []*Message, options *SendChunkedOptions) (*SendChunkedResult, error)
+	WaitForCompletion(ctx context.Context, bulkEmailID string, interval time.Duration) (*BulkEmailRoot, *Response, error)
 }
 
 type bulkEmailService struct {
@@ -21,36 +41,245 @@ type bulkEmailService struct {
 type BulkEmailResponse struct {
 	Message     string `json:"message"`
 	BulkEmailID string `json:"bulk_email_id"`
+
+	// Suppressed are the recipients removed by the SuppressionGuard.
+	Suppressed []SuppressedRecipient `json:"-"`
+	// Dropped are the indexes of the messages left out of the request because the SuppressionGuard
+	// removed all their "to" recipients. Status reports the other messages by their position in the
+	// request that was sent.
+	Dropped []int `json:"-"`
 }
 
 type BulkEmailRoot struct {
 	Data BulkEmailData `json:"data"`
 }
 type BulkEmailData struct {
-	ID                        string      `json:"id"`
-	State                     string      `json:"state"`
-	TotalRecipientsCount      int         `json:"total_recipients_count"`
-	SuppressedRecipientsCount int         `json:"suppressed_recipients_count"`
-	SuppressedRecipients      interface{} `json:"suppressed_recipients"`
-	ValidationErrorsCount     int         `json:"validation_errors_count"`
-	ValidationErrors          interface{} `json:"validation_errors"`
-	MessagesID                []string    `json:"messages_id"`
-	CreatedAt                 time.Time   `json:"created_at"`
-	UpdatedAt                 time.Time   `json:"updated_at"`
+	ID                        string                        `json:"id"`
+	State                     string                        `json:"state"`
+	TotalRecipientsCount      int                           `json:"total_recipients_count"`
+	SuppressedRecipientsCount int                           `json:"suppressed_recipients_count"`
+	SuppressedRecipients      BulkEmailSuppressedRecipients `json:"suppressed_recipients"`
+	ValidationErrorsCount     int                           `json:"validation_errors_count"`
+	ValidationErrors          BulkEmailValidationErrors     `json:"validation_errors"`
+	MessagesID                []string                      `json:"messages_id"`
+	CreatedAt                 time.Time                     `json:"created_at"`
+	UpdatedAt                 time.Time                     `json:"updated_at"`
+}
+
+// BulkEmailValidationError - the field errors of a message of the bulk email that was not sent
+type BulkEmailValidationError struct {
+	// MessageIndex is the index of the message in the bulk email request, -1 if unknown
+	MessageIndex int
+	Errors       map[string][]string
+}
+
+// BulkEmailValidationErrors - decodes the validation errors of a bulk email, keyed like "message.1" by the API
+type BulkEmailValidationErrors []BulkEmailValidationError
+
+func (e *BulkEmailValidationErrors) UnmarshalJSON(data []byte) error {
+	var raw map[string]map[string][]string
+	if err := unmarshalBulkEmailObject(data, &raw); err != nil {
+		return err
+	}
+
+	errors := BulkEmailValidationErrors{}
+	for key, fields := range raw {
+		errors = append(errors, BulkEmailValidationError{MessageIndex: bulkEmailMessageIndex(key), Errors: fields})
+	}
+	sort.SliceStable(errors, func(i, j int) bool { return errors[i].MessageIndex < errors[j].MessageIndex })
+
+	*e = errors
+	return nil
+}
+
+func (e BulkEmailValidationErrors) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+
+	raw := map[string]map[string][]string{}
+	for _, err := range e {
+		raw[bulkEmailMessageKey(err.MessageIndex)] = err.Errors
+	}
+
+	return json.Marshal(raw)
+}
+
+// BulkEmailSuppressedRecipient - a recipient of the bulk email that was not sent to because it is suppressed
+type BulkEmailSuppressedRecipient struct {
+	// MessageIndex is the index of the message in the bulk email request, -1 if unknown
+	MessageIndex int
+	// Field is the recipient field of the message, like "to"
+	Field   string
+	Email   string
+	Reasons []string
+}
+
+// BulkEmailSuppressedRecipients - decodes the suppressed recipients of a bulk email, keyed like "message.1" by the API
+type BulkEmailSuppressedRecipients []BulkEmailSuppressedRecipient
+
+func (r *BulkEmailSuppressedRecipients) UnmarshalJSON(data []byte) error {
+	var raw map[string]map[string]json.RawMessage
+	if err := unmarshalBulkEmailObject(data, &raw); err != nil {
+		return err
+	}
+
+	recipients := BulkEmailSuppressedRecipients{}
+	for key, fields := range raw {
+		for field, value := range fields {
+			// recipients map to their suppression reasons, or are listed without them
+			var reasons map[string][]string
+			if err := unmarshalBulkEmailObject(value, &reasons); err != nil {
+				var emails []string
+				if err := json.Unmarshal(value, &emails); err != nil {
+					return err
+				}
+				reasons = make(map[string][]string, len(emails))
+				for _, email := range emails {
+					reasons[email] = nil
+				}
+			}
+
+			for email, emailReasons := range reasons {
+				recipients = append(recipients, BulkEmailSuppressedRecipient{
+					MessageIndex: bulkEmailMessageIndex(key),
+					Field:        field,
+					Email:        email,
+					Reasons:      emailReasons,
+				})
+			}
+		}
+	}
+	sort.SliceStable(recipients, func(i, j int) bool {
+		a, b := recipients[i], recipients[j]
+		if a.MessageIndex != b.MessageIndex {
+			return a.MessageIndex < b.MessageIndex
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		return a.Email < b.Email
+	})
+
+	*r = recipients
+	return nil
+}
+
+func (r BulkEmailSuppressedRecipients) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+
+	raw := map[string]map[string]map[string][]string{}
+	for _, recipient := range r {
+		key := bulkEmailMessageKey(recipient.MessageIndex)
+		if raw[key] == nil {
+			raw[key] = map[string]map[string][]string{}
+		}
+		if raw[key][recipient.Field] == nil {
+			raw[key][recipient.Field] = map[string][]string{}
+		}
+		raw[key][recipient.Field][recipient.Email] = recipient.Reasons
+	}
+
+	return json.Marshal(raw)
+}
+
+// unmarshalBulkEmailObject decodes a JSON object into v, leaving it empty for null and the empty array the API sends instead of {}.
+func unmarshalBulkEmailObject(data []byte, v interface{}) error {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.Equal(trimmed, []byte("null")) || bytes.Equal(bytes.Join(bytes.Fields(trimmed), nil), []byte("[]")) {
+		return json.Unmarshal([]byte("{}"), v)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func bulkEmailMessageIndex(key string) int {
+	index, err := strconv.Atoi(strings.TrimPrefix(key, "message."))
+	if err != nil {
+		return -1
+	}
+	return index
+}
+
+func bulkEmailMessageKey(index int) string {
+	return fmt.Sprintf("message.%d", index)
+}
+
+// SendChunkedOptions - modifies the behavior of BulkEmailService.SendChunked Method
+type SendChunkedOptions struct {
+	// ChunkSize is the number of messages per request, it defaults to and is capped at MaxBulkEmailMessages
+	ChunkSize int
+	// Concurrency is the number of requests in flight, it defaults to 1
+	Concurrency int
+}
+
+// SendChunkedResult - outcome of BulkEmailService.SendChunked
+type SendChunkedResult struct {
+	// BulkEmailIDs of the accepted chunks, in chunk order
+	BulkEmailIDs []string
+	// Chunks reports every chunk, in chunk order
+	Chunks []BulkEmailChunk
+}
+
+// BulkEmailChunk - outcome of a single bulk email request of BulkEmailService.SendChunked
+type BulkEmailChunk struct {
+	// Offset is the index of the first message of the chunk
+	Offset      int
+	Count       int
+	BulkEmailID string
+	Response    *Response
+	Err         error
+	// Suppressed and Dropped are reported like in BulkEmailResponse, indexed from the first message of all chunks.
+	Suppressed []SuppressedRecipient
+	Dropped    []int
+}
+
+// ChunkedSendError - returned by BulkEmailService.SendChunked when some chunks were not accepted
+type ChunkedSendError struct {
+	Failed []BulkEmailChunk
+}
+
+func (e *ChunkedSendError) Error() string {
+	messages := make([]string, 0, len(e.Failed))
+	for _, chunk := range e.Failed {
+		messages = append(messages, fmt.Sprintf("messages %d-%d: %v", chunk.Offset, chunk.Offset+chunk.Count-1, chunk.Err))
+	}
+
+	return fmt.Sprintf("mailersend: %d bulk email chunks failed: %s", len(e.Failed), strings.Join(messages, "; "))
 }
 
 // Send - send bulk messages
 func (s *bulkEmailService) Send(ctx context.Context, message []*Message) (*BulkEmailResponse, *Response, error) {
-	req, err := s.client.newRequest(http.MethodPost, bulkEmailBasePath, message)
+	state := s.client.snapshot()
+	message, suppressed, dropped, err := state.guardBulk(message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkMessageSize(message...); err != nil {
+		return nil, nil, err
+	}
+
+	if state.validation {
+		if err := validateMessages(message); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	req, err := state.newRequest(http.MethodPost, bulkEmailBasePath, message)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	root := new(BulkEmailResponse)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "BulkEmail.Send", req, root)
 	if err != nil {
 		return nil, res, err
 	}
+	root.Suppressed = suppressed
+	root.Dropped = dropped
 
 	return root, res, nil
 }
@@ -64,10 +293,122 @@ func (s *bulkEmailService) Status(ctx context.Context, bulkEmailID string) (*Bul
 	}
 
 	root := new(BulkEmailRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "BulkEmail.Status", req, root)
 	if err != nil {
 		return nil, res, err
 	}
 
 	return root, res, nil
 }
+
+// SendChunked - send any number of messages, split into bulk email requests the API accepts.
+// The result reports every chunk; when some chunks fail the error is a *ChunkedSendError.
+func (s *bulkEmailService) SendChunked(ctx context.Context, messages []*Message, options *SendChunkedOptions) (*SendChunkedResult, error) {
+	opts := SendChunkedOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.ChunkSize <= 0 || opts.ChunkSize > MaxBulkEmailMessages {
+		opts.ChunkSize = MaxBulkEmailMessages
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	result := &SendChunkedResult{}
+	for offset := 0; offset < len(messages); offset += opts.ChunkSize {
+		count := opts.ChunkSize
+		if offset+count > len(messages) {
+			count = len(messages) - offset
+		}
+		result.Chunks = append(result.Chunks, BulkEmailChunk{Offset: offset, Count: count})
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, opts.Concurrency)
+
+	for i := range result.Chunks {
+		chunk := &result.Chunks[i]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			chunk.Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			root, res, err := s.Send(ctx, messages[chunk.Offset:chunk.Offset+chunk.Count])
+			chunk.Response = res
+			chunk.Err = err
+			if err == nil {
+				chunk.BulkEmailID = root.BulkEmailID
+				for _, recipient := range root.Suppressed {
+					recipient.MessageIndex += chunk.Offset
+					chunk.Suppressed = append(chunk.Suppressed, recipient)
+				}
+				for _, index := range root.Dropped {
+					chunk.Dropped = append(chunk.Dropped, index+chunk.Offset)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	failed := &ChunkedSendError{}
+	for _, chunk := range result.Chunks {
+		if chunk.Err != nil {
+			failed.Failed = append(failed.Failed, chunk)
+			continue
+		}
+		result.BulkEmailIDs = append(result.BulkEmailIDs, chunk.BulkEmailID)
+	}
+
+	if len(failed.Failed) > 0 {
+		return result, failed
+	}
+
+	return result, nil
+}
+
+// WaitForCompletion - polls the status of the bulk email until it is completed or failed.
+// The polling interval starts at interval, 1 second if not set, and backs off to at most 30 seconds or interval, whichever is larger.
+func (s *bulkEmailService) WaitForCompletion(ctx context.Context, bulkEmailID string, interval time.Duration) (*BulkEmailRoot, *Response, error) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := maxBulkEmailPollInterval
+	if interval > maxInterval {
+		maxInterval = interval
+	}
+
+	for {
+		root, res, err := s.Status(ctx, bulkEmailID)
+		if err != nil {
+			return nil, res, err
+		}
+
+		switch root.Data.State {
+		case BulkEmailStateCompleted, BulkEmailStateFailed:
+			return root, res, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return root, res, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = interval * 3 / 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
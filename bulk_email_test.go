@@ -0,0 +1,176 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendChunked(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	var (
+		mu    sync.Mutex
+		sizes []int
+	)
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		var messages []mailersend.Message
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&messages))
+
+		mu.Lock()
+		sizes = append(sizes, len(messages))
+		mu.Unlock()
+
+		if messages[0].Subject == "fail" {
+			return &http.Response{
+				StatusCode: http.StatusUnprocessableEntity,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "The 0.to field is required."}`)),
+				Request:    req,
+			}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"bulk_email_id": "bulk-%s"}`, messages[0].Subject))),
+		}
+	}))
+
+	var messages []*mailersend.Message
+	for i := 0; i < 5; i++ {
+		message := basicEmailNew()
+		message.SetSubject(fmt.Sprint(i))
+		messages = append(messages, message)
+	}
+	messages[2].SetSubject("fail")
+
+	result, err := ms.BulkEmail.SendChunked(context.TODO(), messages, &mailersend.SendChunkedOptions{ChunkSize: 2, Concurrency: 2})
+
+	var chunkedErr *mailersend.ChunkedSendError
+	assert.True(t, errors.As(err, &chunkedErr))
+	assert.Len(t, chunkedErr.Failed, 1)
+	assert.Equal(t, 2, chunkedErr.Failed[0].Offset)
+	assert.True(t, mailersend.IsValidationError(chunkedErr.Failed[0].Err))
+
+	assert.Equal(t, []string{"bulk-0", "bulk-4"}, result.BulkEmailIDs)
+	assert.Len(t, result.Chunks, 3)
+	assert.Equal(t, 1, result.Chunks[2].Count)
+	assert.ElementsMatch(t, []int{2, 2, 1}, sizes)
+}
+
+func TestSendChunkedDefaultsToMaxChunkSize(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"bulk_email_id": "bulk"}`)),
+		}
+	}))
+
+	messages := make([]*mailersend.Message, mailersend.MaxBulkEmailMessages+1)
+	for i := range messages {
+		messages[i] = basicEmailNew()
+	}
+
+	result, err := ms.BulkEmail.SendChunked(context.TODO(), messages, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.BulkEmailIDs, 2)
+	assert.Equal(t, 2, calls)
+}
+
+func TestBulkEmailStatusDecodesFailures(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(bytes.NewBufferString(`{
+				"data": {
+					"id": "bulk-email-id",
+					"state": "completed",
+					"validation_errors_count": 1,
+					"validation_errors": {
+						"message.1": {"from.email": ["The from.email must be verified."]}
+					},
+					"suppressed_recipients_count": 2,
+					"suppressed_recipients": {
+						"message.0": {"to": {"b@client.com": ["unsubscribed"], "a@client.com": ["hard_bounced", "blocklisted"]}}
+					}
+				}
+			}`)),
+		}
+	}))
+
+	root, _, err := ms.BulkEmail.Status(context.TODO(), "bulk-email-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, mailersend.BulkEmailValidationErrors{
+		{MessageIndex: 1, Errors: map[string][]string{"from.email": {"The from.email must be verified."}}},
+	}, root.Data.ValidationErrors)
+	assert.Equal(t, mailersend.BulkEmailSuppressedRecipients{
+		{MessageIndex: 0, Field: "to", Email: "a@client.com", Reasons: []string{"hard_bounced", "blocklisted"}},
+		{MessageIndex: 0, Field: "to", Email: "b@client.com", Reasons: []string{"unsubscribed"}},
+	}, root.Data.SuppressedRecipients)
+}
+
+func TestBulkEmailStatusDecodesEmptyFailures(t *testing.T) {
+	var data mailersend.BulkEmailData
+
+	err := json.Unmarshal([]byte(`{"validation_errors": [], "suppressed_recipients": null}`), &data)
+
+	assert.NoError(t, err)
+	assert.Empty(t, data.ValidationErrors)
+	assert.Empty(t, data.SuppressedRecipients)
+}
+
+func TestWaitForCompletion(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	states := []string{"queued", "processing", "completed"}
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		state := states[calls]
+		calls++
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"data": {"id": "bulk-email-id", "state": %q}}`, state))),
+		}
+	}))
+
+	root, _, err := ms.BulkEmail.WaitForCompletion(context.TODO(), "bulk-email-id", time.Millisecond)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mailersend.BulkEmailStateCompleted, root.Data.State)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWaitForCompletionStopsOnCancel(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": {"id": "bulk-email-id", "state": "queued"}}`)),
+		}
+	}))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+
+	root, _, err := ms.BulkEmail.WaitForCompletion(ctx, "bulk-email-id", 5*time.Millisecond)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, mailersend.BulkEmailStateQueued, root.Data.State)
+}
@@ -0,0 +1,182 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// serviceCalls returns a call for every method of every service of ms, made with placeholder arguments.
+func serviceCalls(ms *mailersend.Mailersend, ctx context.Context) map[string]func() {
+	calls := map[string]func(){}
+
+	client := reflect.ValueOf(ms).Elem()
+	for i := 0; i < client.NumField(); i++ {
+		field := client.Type().Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Interface {
+			continue
+		}
+
+		service := client.Field(i)
+		for m := 0; m < service.NumMethod(); m++ {
+			method := service.Method(m)
+			name := field.Name + "." + service.Type().Method(m).Name
+
+			args := make([]reflect.Value, method.Type().NumIn())
+			for a := range args {
+				switch in := method.Type().In(a); {
+				case in == reflect.TypeOf((*context.Context)(nil)).Elem():
+					args[a] = reflect.ValueOf(ctx)
+				case in == reflect.TypeOf((*io.Writer)(nil)).Elem():
+					args[a] = reflect.ValueOf(io.Discard)
+				case in == reflect.TypeOf((*io.Reader)(nil)).Elem():
+					args[a] = reflect.ValueOf(strings.NewReader(""))
+				case in.Kind() == reflect.String:
+					args[a] = reflect.ValueOf("id").Convert(in)
+				case in.Kind() == reflect.Ptr:
+					args[a] = reflect.New(in.Elem())
+				default:
+					args[a] = reflect.Zero(in)
+				}
+			}
+
+			calls[name] = func() { method.Call(args) }
+		}
+	}
+
+	return calls
+}
+
+func TestConcurrentUseWithKeyRotation(t *testing.T) {
+	ms := mailersend.NewMailersend("key-0")
+
+	transport := func(key string) *http.Client {
+		return NewTestClient(func(req *http.Request) *http.Response {
+			assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"), "Bearer key-"), req.URL.String())
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"X-Client": []string{key}},
+				Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+			}
+		})
+	}
+	ms.SetClient(transport("key-0"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	calls := serviceCalls(ms, ctx)
+	assert.Greater(t, len(calls), 100)
+
+	done := make(chan struct{})
+	var rotations sync.WaitGroup
+	rotations.Add(1)
+	go func() {
+		defer rotations.Done()
+		for i := 1; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			key := fmt.Sprintf("key-%d", i)
+			ms.SetAPIKey(key)
+			ms.SetClient(transport(key))
+			ms.SetRetryPolicy(&mailersend.RetryPolicy{MaxAttempts: 1})
+			ms.SetValidation(i%2 == 0)
+			if i <= 10 {
+				ms.Use(func(next mailersend.Doer) mailersend.Doer { return next })
+			}
+			_ = ms.WithAPIKey(key).APIKey()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for name, call := range calls {
+		wg.Add(1)
+		go func(name string, call func()) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%s panicked: %v", name, r)
+				}
+			}()
+
+			call()
+		}(name, call)
+	}
+	wg.Wait()
+
+	close(done)
+	rotations.Wait()
+}
+
+func TestWithAPIKeyCopiesClient(t *testing.T) {
+	var keys []string
+	ms := mailersend.NewMailersend("key-a")
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		keys = append(keys, req.Header.Get("Authorization"))
+		return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody, Header: http.Header{}}
+	}))
+
+	copied := ms.WithAPIKey("key-b")
+
+	_, err := copied.Email.Send(context.TODO(), basicEmailNew())
+	assert.NoError(t, err)
+	_, err = ms.Email.Send(context.TODO(), basicEmailNew())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Bearer key-b", "Bearer key-a"}, keys)
+	assert.Equal(t, "key-a", ms.APIKey())
+	assert.Same(t, ms.Client(), copied.Client())
+}
+
+// rotatingStore changes the client between building a request and sending it.
+type rotatingStore struct {
+	*mailersend.MemoryIdempotencyStore
+	rotate func()
+}
+
+func (s *rotatingStore) SetIfAbsent(ctx context.Context, key string, messageID string, ttl time.Duration) (string, bool, error) {
+	s.rotate()
+	return s.MemoryIdempotencyStore.SetIfAbsent(ctx, key, messageID, ttl)
+}
+
+func TestRequestUsesConfigurationItWasBuiltWith(t *testing.T) {
+	transport := func(name string, sent *[]string) *http.Client {
+		return NewTestClient(func(req *http.Request) *http.Response {
+			*sent = append(*sent, name+" "+req.Header.Get("Authorization"))
+			return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody, Header: http.Header{}}
+		})
+	}
+
+	var sent []string
+	ms := mailersend.NewMailersend("key-a")
+	ms.SetClient(transport("client-a", &sent))
+	ms.SetIdempotencyStore(&rotatingStore{
+		MemoryIdempotencyStore: mailersend.NewMemoryIdempotencyStore(),
+		rotate: func() {
+			ms.SetAPIKey("key-b")
+			ms.SetClient(transport("client-b", &sent))
+		},
+	}, time.Hour)
+
+	message := basicEmailNew()
+	message.IdempotencyKey = "order-1"
+
+	_, err := ms.Email.Send(context.TODO(), message)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"client-a Bearer key-a"}, sent)
+}
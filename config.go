@@ -0,0 +1,303 @@
+package mailersend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Environment variables read by NewMailersendFromEnv
+const (
+	EnvAPIKey           = "MAILERSEND_API_KEY"
+	EnvConfigFile       = "MAILERSEND_CONFIG"
+	EnvBaseURL          = "MAILERSEND_BASE_URL"
+	EnvTimeout          = "MAILERSEND_TIMEOUT"
+	EnvRetryMaxAttempts = "MAILERSEND_RETRY_MAX_ATTEMPTS"
+	EnvRetryMinBackoff  = "MAILERSEND_RETRY_MIN_BACKOFF"
+	EnvRetryMaxBackoff  = "MAILERSEND_RETRY_MAX_BACKOFF"
+	EnvFromEmail        = "MAILERSEND_FROM_EMAIL"
+	EnvFromName         = "MAILERSEND_FROM_NAME"
+	EnvTrackClicks      = "MAILERSEND_TRACK_CLICKS"
+	EnvTrackOpens       = "MAILERSEND_TRACK_OPENS"
+	EnvTrackContent     = "MAILERSEND_TRACK_CONTENT"
+)
+
+// ErrMissingAPIKey - no api key was found in the environment or the config file
+var ErrMissingAPIKey = errors.New("mailersend: " + EnvAPIKey + " is not set")
+
+// Config - client configuration loaded by LoadConfig or NewMailersendFromEnv
+type Config struct {
+	APIKey  string
+	BaseURL string
+	Timeout time.Duration
+	Retry   *RetryPolicy
+
+	// From and Settings are applied to every message created by EmailService.NewMessage.
+	From     *From
+	Settings *Settings
+}
+
+// Options - returns the options that configure a client like c, for NewMailersend
+func (c *Config) Options() []Option {
+	var opts []Option
+	if c.BaseURL != "" {
+		opts = append(opts, WithBaseURL(c.BaseURL))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, WithTimeout(c.Timeout))
+	}
+	if c.Retry != nil {
+		opts = append(opts, WithRetry(c.Retry))
+	}
+	if c.From != nil {
+		opts = append(opts, WithDefaultFrom(*c.From))
+	}
+	if c.Settings != nil {
+		opts = append(opts, WithDefaultSettings(*c.Settings))
+	}
+
+	return opts
+}
+
+// ConfigDecoder - decodes a config file, like json.Unmarshal, yaml.Unmarshal or toml.Unmarshal
+type ConfigDecoder func(data []byte, v interface{}) error
+
+var configDecoders = struct {
+	mu       sync.RWMutex
+	decoders map[string]ConfigDecoder
+}{decoders: map[string]ConfigDecoder{".json": json.Unmarshal}}
+
+// RegisterConfigDecoder - lets LoadConfig read files with the extension ext, for example
+// RegisterConfigDecoder(".yaml", yaml.Unmarshal). Only .json files are supported by default,
+// so the SDK doesn't depend on a YAML or TOML library. Keys are matched by json, yaml and toml tags.
+func RegisterConfigDecoder(ext string, decode ConfigDecoder) {
+	configDecoders.mu.Lock()
+	defer configDecoders.mu.Unlock()
+
+	configDecoders.decoders[strings.ToLower(ext)] = decode
+}
+
+func configDecoder(ext string) (ConfigDecoder, bool) {
+	configDecoders.mu.RLock()
+	defer configDecoders.mu.RUnlock()
+
+	decode, ok := configDecoders.decoders[ext]
+	return decode, ok
+}
+
+// configFile is the layout of config files, durations are written like "10s".
+type configFile struct {
+	APIKey   string          `json:"api_key" yaml:"api_key" toml:"api_key"`
+	BaseURL  string          `json:"base_url" yaml:"base_url" toml:"base_url"`
+	Timeout  string          `json:"timeout" yaml:"timeout" toml:"timeout"`
+	Retry    *retryConfig    `json:"retry" yaml:"retry" toml:"retry"`
+	From     *fromConfig     `json:"from" yaml:"from" toml:"from"`
+	Settings *settingsConfig `json:"settings" yaml:"settings" toml:"settings"`
+}
+
+type retryConfig struct {
+	MaxAttempts int     `json:"max_attempts" yaml:"max_attempts" toml:"max_attempts"`
+	MinBackoff  string  `json:"min_backoff" yaml:"min_backoff" toml:"min_backoff"`
+	MaxBackoff  string  `json:"max_backoff" yaml:"max_backoff" toml:"max_backoff"`
+	Jitter      float64 `json:"jitter" yaml:"jitter" toml:"jitter"`
+}
+
+type fromConfig struct {
+	Email string `json:"email" yaml:"email" toml:"email"`
+	Name  string `json:"name" yaml:"name" toml:"name"`
+}
+
+type settingsConfig struct {
+	TrackClicks  bool `json:"track_clicks" yaml:"track_clicks" toml:"track_clicks"`
+	TrackOpens   bool `json:"track_opens" yaml:"track_opens" toml:"track_opens"`
+	TrackContent bool `json:"track_content" yaml:"track_content" toml:"track_content"`
+}
+
+// LoadConfig - reads the configuration from a .json file, or a file with an extension
+// registered by RegisterConfigDecoder. Retry settings missing from the file are taken from
+// DefaultRetryPolicy.
+func LoadConfig(path string) (*Config, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	decode, ok := configDecoder(ext)
+	if !ok {
+		return nil, fmt.Errorf("mailersend: unsupported config file format %q, see RegisterConfigDecoder", ext)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file configFile
+	if err := decode(data, &file); err != nil {
+		return nil, fmt.Errorf("mailersend: parsing %s: %w", path, err)
+	}
+
+	cfg, err := file.config()
+	if err != nil {
+		return nil, fmt.Errorf("mailersend: parsing %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func (f *configFile) config() (*Config, error) {
+	cfg := &Config{
+		APIKey:  f.APIKey,
+		BaseURL: f.BaseURL,
+	}
+	if f.From != nil {
+		cfg.From = &From{Email: f.From.Email, Name: f.From.Name}
+	}
+	if f.Settings != nil {
+		cfg.Settings = &Settings{
+			TrackClicks:  f.Settings.TrackClicks,
+			TrackOpens:   f.Settings.TrackOpens,
+			TrackContent: f.Settings.TrackContent,
+		}
+	}
+
+	var err error
+	if cfg.Timeout, err = parseConfigDuration("timeout", f.Timeout); err != nil {
+		return nil, err
+	}
+
+	if f.Retry != nil {
+		cfg.Retry = DefaultRetryPolicy()
+		if f.Retry.MaxAttempts != 0 {
+			cfg.Retry.MaxAttempts = f.Retry.MaxAttempts
+		}
+		if f.Retry.Jitter != 0 {
+			cfg.Retry.Jitter = f.Retry.Jitter
+		}
+		if f.Retry.MinBackoff != "" {
+			if cfg.Retry.MinBackoff, err = parseConfigDuration("retry.min_backoff", f.Retry.MinBackoff); err != nil {
+				return nil, err
+			}
+		}
+		if f.Retry.MaxBackoff != "" {
+			if cfg.Retry.MaxBackoff, err = parseConfigDuration("retry.max_backoff", f.Retry.MaxBackoff); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// NewMailersendFromEnv - creates a client configured by the MAILERSEND_* environment variables.
+// When MAILERSEND_CONFIG names a config file it is loaded first, and the environment variables
+// override it. opts are applied last.
+func NewMailersendFromEnv(opts ...Option) (*Mailersend, error) {
+	cfg := &Config{}
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		var err error
+		if cfg, err = LoadConfig(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if cfg.APIKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	return NewMailersend(cfg.APIKey, append(cfg.Options(), opts...)...), nil
+}
+
+func (c *Config) applyEnv() error {
+	if value, ok := os.LookupEnv(EnvAPIKey); ok {
+		c.APIKey = value
+	}
+	if value, ok := os.LookupEnv(EnvBaseURL); ok {
+		c.BaseURL = value
+	}
+
+	var err error
+	if value, ok := os.LookupEnv(EnvTimeout); ok {
+		if c.Timeout, err = parseConfigDuration(EnvTimeout, value); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range []string{EnvRetryMaxAttempts, EnvRetryMinBackoff, EnvRetryMaxBackoff} {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if c.Retry == nil {
+			c.Retry = DefaultRetryPolicy()
+		}
+
+		switch name {
+		case EnvRetryMaxAttempts:
+			if c.Retry.MaxAttempts, err = strconv.Atoi(value); err != nil {
+				return fmt.Errorf("mailersend: invalid %s %q", name, value)
+			}
+		case EnvRetryMinBackoff:
+			c.Retry.MinBackoff, err = parseConfigDuration(name, value)
+		case EnvRetryMaxBackoff:
+			c.Retry.MaxBackoff, err = parseConfigDuration(name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	email, hasEmail := os.LookupEnv(EnvFromEmail)
+	name, hasName := os.LookupEnv(EnvFromName)
+	if hasEmail || hasName {
+		if c.From == nil {
+			c.From = &From{}
+		}
+		if hasEmail {
+			c.From.Email = email
+		}
+		if hasName {
+			c.From.Name = name
+		}
+	}
+
+	tracking := map[string]func(s *Settings) *bool{
+		EnvTrackClicks:  func(s *Settings) *bool { return &s.TrackClicks },
+		EnvTrackOpens:   func(s *Settings) *bool { return &s.TrackOpens },
+		EnvTrackContent: func(s *Settings) *bool { return &s.TrackContent },
+	}
+	for name, field := range tracking {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("mailersend: invalid %s %q", name, value)
+		}
+		if c.Settings == nil {
+			c.Settings = &Settings{}
+		}
+		*field(c.Settings) = enabled
+	}
+
+	return nil
+}
+
+func parseConfigDuration(name string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("mailersend: invalid %s %q", name, value)
+	}
+
+	return duration, nil
+}
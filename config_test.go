@@ -0,0 +1,102 @@
+package mailersend_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var configFiles = map[string]string{
+	"mailersend.json": `{
+  "api_key": "file-key",
+  "base_url": "https://mailersend.internal/v1",
+  "timeout": "10s",
+  "retry": {"max_attempts": 5, "min_backoff": "1s"},
+  "from": {"email": "noreply@example.com", "name": "Example"},
+  "settings": {"track_clicks": true, "track_opens": true}
+}`,
+}
+
+func writeConfig(t *testing.T, name string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(configFiles[name]), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	for name := range configFiles {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := mailersend.LoadConfig(writeConfig(t, name))
+
+			assert.NoError(t, err)
+			assert.Equal(t, "file-key", cfg.APIKey)
+			assert.Equal(t, "https://mailersend.internal/v1", cfg.BaseURL)
+			assert.Equal(t, 10*time.Second, cfg.Timeout)
+			assert.Equal(t, 5, cfg.Retry.MaxAttempts)
+			assert.Equal(t, time.Second, cfg.Retry.MinBackoff)
+			assert.Equal(t, mailersend.DefaultRetryPolicy().MaxBackoff, cfg.Retry.MaxBackoff)
+			assert.Equal(t, &mailersend.From{Email: "noreply@example.com", Name: "Example"}, cfg.From)
+			assert.Equal(t, &mailersend.Settings{TrackClicks: true, TrackOpens: true}, cfg.Settings)
+		})
+	}
+}
+
+func TestLoadConfigWithRegisteredDecoder(t *testing.T) {
+	var decoded []byte
+	mailersend.RegisterConfigDecoder(".JSONC", func(data []byte, v interface{}) error {
+		decoded = data
+		return json.Unmarshal(data, v)
+	})
+
+	path := filepath.Join(t.TempDir(), "mailersend.jsonc")
+	assert.NoError(t, os.WriteFile(path, []byte(configFiles["mailersend.json"]), 0o600))
+
+	cfg, err := mailersend.LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, configFiles["mailersend.json"], string(decoded))
+	assert.Equal(t, "file-key", cfg.APIKey)
+	assert.Equal(t, &mailersend.From{Email: "noreply@example.com", Name: "Example"}, cfg.From)
+}
+
+func TestLoadConfigRejectsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	yaml := filepath.Join(dir, "mailersend.yaml")
+	assert.NoError(t, os.WriteFile(yaml, []byte("api_key: key"), 0o600))
+	_, err := mailersend.LoadConfig(yaml)
+	assert.ErrorContains(t, err, "unsupported config file format")
+
+	timeout := filepath.Join(dir, "mailersend.json")
+	assert.NoError(t, os.WriteFile(timeout, []byte(`{"timeout": "soon"}`), 0o600))
+	_, err = mailersend.LoadConfig(timeout)
+	assert.ErrorContains(t, err, `invalid timeout "soon"`)
+}
+
+func TestNewMailersendFromEnv(t *testing.T) {
+	t.Setenv(mailersend.EnvConfigFile, writeConfig(t, "mailersend.json"))
+	t.Setenv(mailersend.EnvAPIKey, "env-key")
+	t.Setenv(mailersend.EnvTimeout, "3s")
+	t.Setenv(mailersend.EnvFromName, "Overridden")
+	t.Setenv(mailersend.EnvTrackOpens, "false")
+
+	ms, err := mailersend.NewMailersendFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, "env-key", ms.APIKey())
+	assert.Equal(t, 3*time.Second, ms.Client().Timeout)
+
+	message := ms.Email.NewMessage()
+	assert.Equal(t, mailersend.From{Email: "noreply@example.com", Name: "Overridden"}, message.From)
+	assert.Equal(t, mailersend.Settings{TrackClicks: true}, message.Settings)
+}
+
+func TestNewMailersendFromEnvRequiresAPIKey(t *testing.T) {
+	t.Setenv(mailersend.EnvAPIKey, "")
+
+	_, err := mailersend.NewMailersendFromEnv()
+	assert.ErrorIs(t, err, mailersend.ErrMissingAPIKey)
+}
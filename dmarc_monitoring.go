@@ -11,12 +11,15 @@ const dmarcMonitoringBasePath = "/dmarc-monitoring"
 // DmarcMonitoringService defines the interface for DMARC Monitoring API operations.
 type DmarcMonitoringService interface {
 	List(ctx context.Context, options *ListDmarcMonitorOptions) (*DmarcMonitorRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListDmarcMonitorOptions) *Iterator[DmarcMonitor]
 	Create(ctx context.Context, options *CreateDmarcMonitorOptions) (*SingleDmarcMonitorRoot, *Response, error)
 	Update(ctx context.Context, options *UpdateDmarcMonitorOptions) (*SingleDmarcMonitorRoot, *Response, error)
 	Delete(ctx context.Context, monitorID string) (*Response, error)
 	GetAggregatedReport(ctx context.Context, options *ListDmarcReportOptions) (*DmarcAggregatedReportRoot, *Response, error)
+	GetAllAggregatedReports(ctx context.Context, options *ListDmarcReportOptions) *Iterator[DmarcAggregatedReport]
 	GetIPReport(ctx context.Context, monitorID string, ip string) (*DmarcIPReportRoot, *Response, error)
 	GetReportSources(ctx context.Context, options *ListDmarcReportSourcesOptions) (*DmarcReportSourcesRoot, *Response, error)
+	GetAllReportSources(ctx context.Context, options *ListDmarcReportSourcesOptions) *Iterator[DmarcReportSource]
 	MarkIPFavorite(ctx context.Context, monitorID string, ip string) (*Response, error)
 	RemoveIPFavorite(ctx context.Context, monitorID string, ip string) (*Response, error)
 }
@@ -142,7 +145,7 @@ func (s *dmarcMonitoringService) List(ctx context.Context, options *ListDmarcMon
 	}
 
 	root := new(DmarcMonitorRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "DmarcMonitoring.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -150,6 +153,24 @@ func (s *dmarcMonitoringService) List(ctx context.Context, options *ListDmarcMon
 	return root, res, nil
 }
 
+func (s *dmarcMonitoringService) ListAll(ctx context.Context, options *ListDmarcMonitorOptions) *Iterator[DmarcMonitor] {
+	opts := ListDmarcMonitorOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]DmarcMonitor, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *dmarcMonitoringService) Create(ctx context.Context, options *CreateDmarcMonitorOptions) (*SingleDmarcMonitorRoot, *Response, error) {
 	req, err := s.client.newRequest(http.MethodPost, dmarcMonitoringBasePath, options)
 	if err != nil {
@@ -157,7 +178,7 @@ func (s *dmarcMonitoringService) Create(ctx context.Context, options *CreateDmar
 	}
 
 	root := new(SingleDmarcMonitorRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "DmarcMonitoring.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -174,7 +195,7 @@ func (s *dmarcMonitoringService) Update(ctx context.Context, options *UpdateDmar
 	}
 
 	root := new(SingleDmarcMonitorRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "DmarcMonitoring.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -190,7 +211,7 @@ func (s *dmarcMonitoringService) Delete(ctx context.Context, monitorID string) (
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "DmarcMonitoring.Delete", req, nil)
 }
 
 func (s *dmarcMonitoringService) GetAggregatedReport(ctx context.Context, options *ListDmarcReportOptions) (*DmarcAggregatedReportRoot, *Response, error) {
@@ -202,7 +223,7 @@ func (s *dmarcMonitoringService) GetAggregatedReport(ctx context.Context, option
 	}
 
 	root := new(DmarcAggregatedReportRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "DmarcMonitoring.GetAggregatedReport", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -210,6 +231,24 @@ func (s *dmarcMonitoringService) GetAggregatedReport(ctx context.Context, option
 	return root, res, nil
 }
 
+func (s *dmarcMonitoringService) GetAllAggregatedReports(ctx context.Context, options *ListDmarcReportOptions) *Iterator[DmarcAggregatedReport] {
+	opts := ListDmarcReportOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]DmarcAggregatedReport, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.GetAggregatedReport(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *dmarcMonitoringService) GetIPReport(ctx context.Context, monitorID string, ip string) (*DmarcIPReportRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s/report/%s", dmarcMonitoringBasePath, monitorID, ip)
 
@@ -219,7 +258,7 @@ func (s *dmarcMonitoringService) GetIPReport(ctx context.Context, monitorID stri
 	}
 
 	root := new(DmarcIPReportRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "DmarcMonitoring.GetIPReport", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -236,7 +275,7 @@ func (s *dmarcMonitoringService) GetReportSources(ctx context.Context, options *
 	}
 
 	root := new(DmarcReportSourcesRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "DmarcMonitoring.GetReportSources", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -244,6 +283,24 @@ func (s *dmarcMonitoringService) GetReportSources(ctx context.Context, options *
 	return root, res, nil
 }
 
+func (s *dmarcMonitoringService) GetAllReportSources(ctx context.Context, options *ListDmarcReportSourcesOptions) *Iterator[DmarcReportSource] {
+	opts := ListDmarcReportSourcesOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]DmarcReportSource, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.GetReportSources(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *dmarcMonitoringService) MarkIPFavorite(ctx context.Context, monitorID string, ip string) (*Response, error) {
 	path := fmt.Sprintf("%s/%s/favorite/%s", dmarcMonitoringBasePath, monitorID, ip)
 
@@ -252,7 +309,7 @@ func (s *dmarcMonitoringService) MarkIPFavorite(ctx context.Context, monitorID s
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "DmarcMonitoring.MarkIPFavorite", req, nil)
 }
 
 func (s *dmarcMonitoringService) RemoveIPFavorite(ctx context.Context, monitorID string, ip string) (*Response, error) {
@@ -263,5 +320,5 @@ func (s *dmarcMonitoringService) RemoveIPFavorite(ctx context.Context, monitorID
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "DmarcMonitoring.RemoveIPFavorite", req, nil)
 }
@@ -10,6 +10,7 @@ const domainBasePath = "/domains"
 
 type DomainService interface {
 	List(ctx context.Context, options *ListDomainOptions) (*DomainRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListDomainOptions) *Iterator[Domain]
 	Get(ctx context.Context, domainID string) (*SingleDomainRoot, *Response, error)
 	Update(ctx context.Context, options *DomainSettingOptions) (*SingleDomainRoot, *Response, error)
 	Delete(ctx context.Context, domainID string) (*Response, error)
@@ -17,6 +18,7 @@ type DomainService interface {
 	GetDNS(ctx context.Context, domainID string) (*DnsRoot, *Response, error)
 	Verify(ctx context.Context, domainID string) (*VerifyRoot, *Response, error)
 	GetRecipients(ctx context.Context, options *GetRecipientsOptions) (*DomainRecipientRoot, *Response, error)
+	GetAllRecipients(ctx context.Context, options *GetRecipientsOptions) *Iterator[DomainRecipient]
 }
 
 type domainService struct {
@@ -184,7 +186,7 @@ func (s *domainService) List(ctx context.Context, options *ListDomainOptions) (*
 	}
 
 	root := new(DomainRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Domain.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -192,6 +194,24 @@ func (s *domainService) List(ctx context.Context, options *ListDomainOptions) (*
 	return root, res, nil
 }
 
+func (s *domainService) ListAll(ctx context.Context, options *ListDomainOptions) *Iterator[Domain] {
+	opts := ListDomainOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]Domain, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *domainService) Get(ctx context.Context, domainID string) (*SingleDomainRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", domainBasePath, domainID)
 
@@ -201,7 +221,7 @@ func (s *domainService) Get(ctx context.Context, domainID string) (*SingleDomain
 	}
 
 	root := new(SingleDomainRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Domain.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -218,7 +238,7 @@ func (s *domainService) Update(ctx context.Context, options *DomainSettingOption
 	}
 
 	root := new(SingleDomainRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Domain.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -234,7 +254,7 @@ func (s *domainService) Delete(ctx context.Context, domainID string) (*Response,
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Domain.Delete", req, nil)
 }
 
 func (s *domainService) Create(ctx context.Context, options *CreateDomainOptions) (*SingleDomainRoot, *Response, error) {
@@ -244,7 +264,7 @@ func (s *domainService) Create(ctx context.Context, options *CreateDomainOptions
 	}
 
 	root := new(SingleDomainRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Domain.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -261,7 +281,7 @@ func (s *domainService) GetDNS(ctx context.Context, domainID string) (*DnsRoot,
 	}
 
 	root := new(DnsRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Domain.GetDNS", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -278,7 +298,7 @@ func (s *domainService) Verify(ctx context.Context, domainID string) (*VerifyRoo
 	}
 
 	root := new(VerifyRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Domain.Verify", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -295,10 +315,28 @@ func (s *domainService) GetRecipients(ctx context.Context, options *GetRecipient
 	}
 
 	root := new(DomainRecipientRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Domain.GetRecipients", req, root)
 	if err != nil {
 		return nil, res, err
 	}
 
 	return root, res, nil
 }
+
+func (s *domainService) GetAllRecipients(ctx context.Context, options *GetRecipientsOptions) *Iterator[DomainRecipient] {
+	opts := GetRecipientsOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]DomainRecipient, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.GetRecipients(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
@@ -10,6 +10,7 @@ const emailBasePath = "/email"
 type EmailService interface {
 	NewMessage() *Message
 	Send(ctx context.Context, message *Message) (*Response, error)
+	SendWithResult(ctx context.Context, message *Message) (*SendResult, error)
 }
 
 type emailService struct {
@@ -21,6 +22,11 @@ const (
 	DispositionAttachment = "attachment"
 )
 
+const (
+	SendWarningAllSuppressed  = "ALL_SUPPRESSED"
+	SendWarningSomeSuppressed = "SOME_SUPPRESSED"
+)
+
 // Message structures contain both the message text and the envelop for an e-mail message.
 type Message struct {
 	Recipients  []Recipient  `json:"to"`
@@ -44,6 +50,9 @@ type Message struct {
 	PrecedenceBulk    bool              `json:"precedence_bulk,omitempty"`
 	References        []string          `json:"references,omitempty"`
 	Settings          Settings          `json:"settings,omitempty"`
+
+	// IdempotencyKey is sent in the Idempotency-Key header, see SetIdempotencyStore.
+	IdempotencyKey string `json:"-"`
 }
 
 // From - simple struct to declare from name/ email
@@ -88,6 +97,9 @@ type Attachment struct {
 	Filename    string `json:"filename"`
 	Disposition string `json:"disposition,omitempty"`
 	ID          string `json:"id,omitempty"`
+
+	// ContentType is detected by NewAttachmentFromFile and NewAttachmentFromReader, it is not sent to the API.
+	ContentType string `json:"-"`
 }
 
 // Settings - you can set email Settings
@@ -97,14 +109,51 @@ type Settings struct {
 	TrackContent bool `json:"track_content"`
 }
 
+// SendResult - outcome of a send, including the message id and any warnings
+type SendResult struct {
+	MessageID  string `json:"-"`
+	SendPaused bool   `json:"-"`
+	// Replayed is true when the message was already sent with the same IdempotencyKey and was not sent again.
+	Replayed bool `json:"-"`
+	// Suppressed are the recipients removed by the SuppressionGuard.
+	Suppressed []SuppressedRecipient `json:"-"`
+	Message    string                `json:"message"`
+	Warnings   []SendWarning         `json:"warnings"`
+	Response   *Response             `json:"-"`
+}
+
+// SendWarning - returned when the message was accepted but not sent to every recipient
+type SendWarning struct {
+	Type       string                 `json:"type"`
+	Warning    string                 `json:"warning"`
+	Recipients []SendWarningRecipient `json:"recipients"`
+}
+
+type SendWarningRecipient struct {
+	Email   string   `json:"email"`
+	Name    string   `json:"name"`
+	Reasons []string `json:"reasons"`
+}
+
 // Deprecated: NewMessage - Setup a new message ready to be sent
 func (ms *Mailersend) NewMessage() *Message {
-	return &Message{}
+	return ms.Email.NewMessage()
 }
 
 // NewMessage - Setup a new email message ready to be sent.
+// The default sender and settings of the client, if any, are already set.
 func (s *emailService) NewMessage() *Message {
-	return &Message{}
+	state := s.client.snapshot()
+
+	message := &Message{}
+	if state.defaultFrom != nil {
+		message.From = *state.defaultFrom
+	}
+	if state.defaultSettings != nil {
+		message.Settings = *state.defaultSettings
+	}
+
+	return message
 }
 
 // SetFrom - Set from.
@@ -214,20 +263,66 @@ func (m *Message) SetSettings(settings Settings) {
 
 // Deprecated: Send - send the message
 func (ms *Mailersend) Send(ctx context.Context, message *Message) (*Response, error) {
-	req, err := ms.newRequest(http.MethodPost, emailBasePath, message)
+	return ms.Email.Send(ctx, message)
+}
+
+// Send - send the message
+func (s *emailService) Send(ctx context.Context, message *Message) (*Response, error) {
+	req, message, _, err := s.newSendRequest(message)
 	if err != nil {
 		return nil, err
 	}
 
-	return ms.do(ctx, req, nil)
+	res, _, err := s.client.sendOnce(ctx, "Email.Send", "email", message.IdempotencyKey, messageIDHeader, req, nil)
+	return res, err
 }
 
-// Send - send the message
-func (s *emailService) Send(ctx context.Context, message *Message) (*Response, error) {
-	req, err := s.client.newRequest(http.MethodPost, emailBasePath, message)
+// SendWithResult - send the message and return its id along with any warnings
+func (s *emailService) SendWithResult(ctx context.Context, message *Message) (*SendResult, error) {
+	req, message, suppressed, err := s.newSendRequest(message)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(SendResult)
+	res, replayed, err := s.client.sendOnce(ctx, "Email.SendWithResult", "email", message.IdempotencyKey, messageIDHeader, req, result)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	result.MessageID = res.Header.Get(messageIDHeader)
+	result.Replayed = replayed
+	result.Suppressed = suppressed
+	result.SendPaused = res.Header.Get("X-Send-Paused") == "true"
+	result.Response = res
+
+	return result, nil
+}
+
+// newSendRequest checks message like every send does and builds its request. It returns the
+// message to send, a copy when the SuppressionGuard removed recipients, and the removed recipients.
+func (s *emailService) newSendRequest(message *Message) (*http.Request, *Message, []SuppressedRecipient, error) {
+	state := s.client.snapshot()
+	messages, suppressed, err := state.guard(message)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	message = messages[0]
+
+	if err := checkMessageSize(message); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if state.validation {
+		if err := message.Validate(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	req, err := state.newRequest(http.MethodPost, emailBasePath, message)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return req, message, suppressed, nil
 }
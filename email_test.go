@@ -2,8 +2,11 @@ package mailersend_test
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/base64"
 	"io"
+	"net/http"
 	"os"
 	"testing"
 
@@ -239,3 +242,60 @@ func TestCanAddAttachments(t *testing.T) {
 	assert.NotNil(t, message, message.Attachments)
 	assert.Len(t, message.Attachments, 1)
 }
+
+func TestSendWithResult(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "https://api.mailersend.com/v1/email", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Header: http.Header{
+				"X-Message-Id":  []string{"message-id"},
+				"X-Send-Paused": []string{"true"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(`{
+				"message": "There are some warnings for your request.",
+				"warnings": [
+					{
+						"type": "SOME_SUPPRESSED",
+						"warning": "Some of the recipients have not received the email.",
+						"recipients": [
+							{"email": "suppressed@example.com", "name": "Suppressed", "reasons": ["hard_bounced"]}
+						]
+					}
+				]
+			}`)),
+		}
+	}))
+
+	result, err := ms.Email.SendWithResult(context.TODO(), basicEmailNew())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "message-id", result.MessageID)
+	assert.True(t, result.SendPaused)
+	assert.Equal(t, http.StatusAccepted, result.Response.StatusCode)
+	assert.Len(t, result.Warnings, 1)
+	assert.Equal(t, mailersend.SendWarningSomeSuppressed, result.Warnings[0].Type)
+	assert.Equal(t, "suppressed@example.com", result.Warnings[0].Recipients[0].Email)
+	assert.Equal(t, []string{"hard_bounced"}, result.Warnings[0].Recipients[0].Reasons)
+}
+
+func TestSendWithResultWithoutBody(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Header:     http.Header{"X-Message-Id": []string{"message-id"}},
+			Body:       io.NopCloser(bytes.NewBufferString(``)),
+		}
+	}))
+
+	result, err := ms.Email.SendWithResult(context.TODO(), basicEmailNew())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "message-id", result.MessageID)
+	assert.False(t, result.SendPaused)
+	assert.Empty(t, result.Warnings)
+}
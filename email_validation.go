@@ -0,0 +1,219 @@
+package mailersend
+
+import (
+	"fmt"
+	"net/mail"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits of the email endpoint, as documented by the API.
+const (
+	MaxRecipients          = 50
+	MaxCcRecipients        = 10
+	MaxBccRecipients       = 10
+	MaxTags                = 5
+	MaxSubjectLength       = 998
+	MaxScheduleAhead       = 72 * time.Hour
+	MaxMessageSize   int64 = 25 * 1024 * 1024
+)
+
+// forbiddenAttachmentExtensions are the file extensions the API refuses to send.
+var forbiddenAttachmentExtensions = map[string]bool{
+	"ade": true, "adp": true, "apk": true, "app": true, "appx": true, "bas": true, "bat": true,
+	"cab": true, "chm": true, "cmd": true, "com": true, "cpl": true, "dll": true, "dmg": true,
+	"exe": true, "hta": true, "ins": true, "isp": true, "iso": true, "jar": true, "js": true,
+	"jse": true, "lib": true, "lnk": true, "mde": true, "msc": true, "msi": true, "msp": true,
+	"mst": true, "nsh": true, "pif": true, "ps1": true, "reg": true, "scr": true, "sct": true,
+	"shb": true, "sys": true, "vb": true, "vbe": true, "vbs": true, "vxd": true, "wsc": true,
+	"wsf": true, "wsh": true,
+}
+
+// validationErrors collects per-field errors in the order they were found.
+type validationErrors struct {
+	prefix string
+	first  string
+	count  int
+	errors map[string][]string
+}
+
+func (v *validationErrors) add(field string, format string, args ...interface{}) {
+	if v.errors == nil {
+		v.errors = make(map[string][]string)
+	}
+
+	message := fmt.Sprintf(format, args...)
+	if v.first == "" {
+		v.first = message
+	}
+	v.count++
+	v.errors[v.prefix+field] = append(v.errors[v.prefix+field], message)
+}
+
+func (v *validationErrors) err() error {
+	if v.count == 0 {
+		return nil
+	}
+
+	message := v.first
+	if v.count > 1 {
+		message = fmt.Sprintf("%s (and %d more errors)", message, v.count-1)
+	}
+
+	return &ValidationError{Message: message, Errors: v.errors}
+}
+
+// Validate - checks the message against the limits of the API before it is sent.
+// It returns a *ValidationError with the same per-field format as the API, or nil.
+func (m *Message) Validate() error {
+	v := &validationErrors{}
+	m.validate(v, time.Now())
+
+	return v.err()
+}
+
+func (m *Message) validate(v *validationErrors, now time.Time) {
+	if m.From.Email == "" {
+		if m.TemplateID == "" {
+			v.add("from.email", "The from.email field is required when template id is not present.")
+		}
+	} else if !validEmail(m.From.Email) {
+		v.add("from.email", "The from.email must be a valid email address.")
+	}
+
+	if len(m.Recipients) == 0 {
+		v.add("to", "The to field is required.")
+	}
+	validateRecipients(v, "to", m.Recipients, MaxRecipients)
+	validateRecipients(v, "cc", m.CC, MaxCcRecipients)
+	validateRecipients(v, "bcc", m.Bcc, MaxBccRecipients)
+
+	if m.ReplyTo.Email != "" && !validEmail(m.ReplyTo.Email) {
+		v.add("reply_to.email", "The reply_to.email must be a valid email address.")
+	}
+
+	if m.TemplateID == "" {
+		if m.Subject == "" {
+			v.add("subject", "The subject field is required when template id is not present.")
+		}
+		if m.Text == "" && m.HTML == "" {
+			v.add("text", "The text field is required when none of html / template id are present.")
+		}
+	}
+	if utf8.RuneCountInString(m.Subject) > MaxSubjectLength {
+		v.add("subject", "The subject may not be greater than %d characters.", MaxSubjectLength)
+	}
+
+	if len(m.Tags) > MaxTags {
+		v.add("tags", "The tags may not have more than %d items.", MaxTags)
+	}
+
+	if m.SendAt != 0 {
+		sendAt := time.Unix(m.SendAt, 0)
+		if sendAt.Before(now) {
+			v.add("send_at", "The send at must be a date after now.")
+		} else if sendAt.After(now.Add(MaxScheduleAhead)) {
+			v.add("send_at", "The send at may not be more than %d hours in the future.", int(MaxScheduleAhead.Hours()))
+		}
+	}
+
+	for i, personalization := range m.Personalization {
+		if personalization.Email == "" {
+			v.add(fmt.Sprintf("personalization.%d.email", i), "The personalization.%d.email field is required.", i)
+		} else if !validEmail(personalization.Email) {
+			v.add(fmt.Sprintf("personalization.%d.email", i), "The personalization.%d.email must be a valid email address.", i)
+		}
+		if personalization.Data == nil {
+			v.add(fmt.Sprintf("personalization.%d.data", i), "The personalization.%d.data field is required.", i)
+		}
+	}
+
+	for i, header := range m.Headers {
+		if header.Name == "" {
+			v.add(fmt.Sprintf("headers.%d.name", i), "The headers.%d.name field is required.", i)
+		}
+		if header.Value == "" {
+			v.add(fmt.Sprintf("headers.%d.value", i), "The headers.%d.value field is required.", i)
+		}
+	}
+
+	validateAttachments(v, m.Attachments)
+}
+
+func validateRecipients(v *validationErrors, field string, recipients []Recipient, max int) {
+	if len(recipients) > max {
+		v.add(field, "The %s may not have more than %d items.", field, max)
+	}
+
+	for i, recipient := range recipients {
+		key := fmt.Sprintf("%s.%d", field, i)
+		if recipient.Email == "" {
+			v.add(key+".email", "The %s.email field is required.", key)
+		} else if !validEmail(recipient.Email) {
+			v.add(key+".email", "The %s.email must be a valid email address.", key)
+		}
+		if strings.ContainsAny(recipient.Name, ";,") {
+			v.add(key+".name", "The %s.name may not contain ; or ,.", key)
+		}
+	}
+}
+
+func validateAttachments(v *validationErrors, attachments []Attachment) {
+	var size int64
+	for i, attachment := range attachments {
+		key := fmt.Sprintf("attachments.%d", i)
+
+		if attachment.Filename == "" {
+			v.add(key+".filename", "The %s.filename field is required.", key)
+		} else if forbiddenAttachment(attachment.Filename) {
+			v.add(key+".filename", "The %s.filename has a file type that is not allowed.", key)
+		}
+
+		if attachment.Content == "" {
+			v.add(key+".content", "The %s.content field is required.", key)
+		}
+		size += attachment.Size()
+
+		switch attachment.Disposition {
+		case "", DispositionAttachment:
+		case DispositionInline:
+			if attachment.ID == "" {
+				v.add(key+".id", "The %s.id field is required when %s.disposition is inline.", key, key)
+			}
+		default:
+			v.add(key+".disposition", "The selected %s.disposition is invalid.", key)
+		}
+	}
+
+	if size > MaxMessageSize {
+		v.add("attachments", "The attachments may not be greater than %d megabytes in total.", MaxMessageSize/1024/1024)
+	}
+}
+
+func forbiddenAttachment(filename string) bool {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	return forbiddenAttachmentExtensions[ext]
+}
+
+func validEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
+
+// validateMessages validates every message of a bulk request, keying errors by message index like the API.
+func validateMessages(messages []*Message) error {
+	v := &validationErrors{}
+	now := time.Now()
+
+	for i, message := range messages {
+		if message == nil {
+			continue
+		}
+		v.prefix = fmt.Sprintf("%d.", i)
+		message.validate(v, now)
+	}
+
+	return v.err()
+}
@@ -0,0 +1,94 @@
+package mailersend_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAcceptsValidMessage(t *testing.T) {
+	message := basicEmailNew()
+	message.SetTags([]string{"welcome"})
+	message.SetSendAt(time.Now().Add(time.Hour).Unix())
+	message.AddAttachment(mailersend.Attachment{Content: "aGVsbG8=", Filename: "hello.txt"})
+
+	assert.NoError(t, message.Validate())
+}
+
+func TestValidateReportsFieldErrors(t *testing.T) {
+	message := &mailersend.Message{}
+	for i := 0; i < 51; i++ {
+		message.Recipients = append(message.Recipients, mailersend.Recipient{Email: fmt.Sprintf("user%d@client.com", i)})
+	}
+	message.Recipients[3].Email = "not-an-email"
+	message.SetTags([]string{"1", "2", "3", "4", "5", "6"})
+	message.SetSendAt(time.Now().Add(73 * time.Hour).Unix())
+	message.SetSubject(strings.Repeat("s", 999))
+	message.SetPersonalization([]mailersend.Personalization{{Email: "user0@client.com"}})
+	message.AddAttachment(mailersend.Attachment{Content: "aGVsbG8=", Filename: "setup.exe"})
+
+	err := message.Validate()
+
+	var validationErr *mailersend.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Nil(t, validationErr.Response)
+	assert.Contains(t, validationErr.Message, "The from.email field is required")
+	for _, field := range []string{"from.email", "to", "to.3.email", "text", "subject", "tags", "send_at", "personalization.0.data", "attachments.0.filename"} {
+		assert.Contains(t, validationErr.Errors, field)
+	}
+}
+
+func TestValidateCountsSubjectCharacters(t *testing.T) {
+	message := basicEmailNew()
+	message.SetSubject(strings.Repeat("件", 400))
+
+	assert.NoError(t, message.Validate())
+
+	message.SetSubject(strings.Repeat("件", mailersend.MaxSubjectLength+1))
+
+	var validationErr *mailersend.ValidationError
+	assert.True(t, errors.As(message.Validate(), &validationErr))
+	assert.Contains(t, validationErr.Errors, "subject")
+}
+
+func TestValidateTemplateMessage(t *testing.T) {
+	message := &mailersend.Message{}
+	message.SetRecipients(recipients)
+	message.SetTemplateID("template-id")
+
+	assert.NoError(t, message.Validate())
+}
+
+func TestSendValidatesWhenEnabled(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody, Header: make(http.Header)}
+	}))
+
+	message := basicEmailNew()
+	message.SetRecipients(nil)
+
+	_, err := ms.Email.Send(context.TODO(), message)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	ms.SetValidation(true)
+
+	_, err = ms.Email.Send(context.TODO(), message)
+	assert.True(t, mailersend.IsValidationError(err))
+
+	_, _, err = ms.BulkEmail.Send(context.TODO(), []*mailersend.Message{basicEmailNew(), message})
+	assert.True(t, mailersend.IsValidationError(err))
+	assert.Contains(t, err.(*mailersend.ValidationError).Errors, "1.to")
+	assert.Equal(t, 1, calls)
+}
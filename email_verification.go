@@ -11,6 +11,7 @@ const emailVerificationBasePath = "/email-verification"
 
 type EmailVerificationService interface {
 	List(ctx context.Context, options *ListEmailVerificationOptions) (*EmailVerificationRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListEmailVerificationOptions) *Iterator[EmailVerification]
 	Get(ctx context.Context, emailVerificationId string) (*SingleEmailVerificationRoot, *Response, error)
 	Update(ctx context.Context, options *DomainSettingOptions) (*SingleEmailVerificationRoot, *Response, error)
 	Delete(ctx context.Context, domainID string) (*Response, error)
@@ -18,6 +19,7 @@ type EmailVerificationService interface {
 	Verify(ctx context.Context, emailVerificationId string) (*SingleEmailVerificationRoot, *Response, error)
 	VerifySingle(ctx context.Context, options *SingleEmailVerificationOptions) (*ResultSingleEmailVerification, *Response, error)
 	GetResults(ctx context.Context, options *GetEmailVerificationOptions) (*ResultEmailVerificationRoot, *Response, error)
+	GetAllResults(ctx context.Context, options *GetEmailVerificationOptions) *Iterator[Result]
 }
 
 type emailVerificationService struct {
@@ -113,7 +115,7 @@ func (s *emailVerificationService) List(ctx context.Context, options *ListEmailV
 	}
 
 	root := new(EmailVerificationRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "EmailVerification.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -121,6 +123,24 @@ func (s *emailVerificationService) List(ctx context.Context, options *ListEmailV
 	return root, res, nil
 }
 
+func (s *emailVerificationService) ListAll(ctx context.Context, options *ListEmailVerificationOptions) *Iterator[EmailVerification] {
+	opts := ListEmailVerificationOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]EmailVerification, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *emailVerificationService) Get(ctx context.Context, emailVerificationId string) (*SingleEmailVerificationRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", emailVerificationBasePath, emailVerificationId)
 
@@ -130,7 +150,7 @@ func (s *emailVerificationService) Get(ctx context.Context, emailVerificationId
 	}
 
 	root := new(SingleEmailVerificationRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "EmailVerification.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -147,7 +167,7 @@ func (s *emailVerificationService) Update(ctx context.Context, options *DomainSe
 	}
 
 	root := new(SingleEmailVerificationRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "EmailVerification.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -163,7 +183,7 @@ func (s *emailVerificationService) Delete(ctx context.Context, domainID string)
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "EmailVerification.Delete", req, nil)
 }
 
 func (s *emailVerificationService) Create(ctx context.Context, options *CreateEmailVerificationOptions) (*SingleEmailVerificationRoot, *Response, error) {
@@ -173,7 +193,7 @@ func (s *emailVerificationService) Create(ctx context.Context, options *CreateEm
 	}
 
 	root := new(SingleEmailVerificationRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "EmailVerification.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -190,7 +210,7 @@ func (s *emailVerificationService) Verify(ctx context.Context, emailVerification
 	}
 
 	root := new(SingleEmailVerificationRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "EmailVerification.Verify", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -207,7 +227,7 @@ func (s *emailVerificationService) VerifySingle(ctx context.Context, options *Si
 	}
 
 	verification := new(ResultSingleEmailVerification)
-	res, err := s.client.do(ctx, req, verification)
+	res, err := s.client.do(ctx, "EmailVerification.VerifySingle", req, verification)
 	if err != nil {
 		return nil, res, err
 	}
@@ -224,10 +244,28 @@ func (s *emailVerificationService) GetResults(ctx context.Context, options *GetE
 	}
 
 	root := new(ResultEmailVerificationRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "EmailVerification.GetResults", req, root)
 	if err != nil {
 		return nil, res, err
 	}
 
 	return root, res, nil
 }
+
+func (s *emailVerificationService) GetAllResults(ctx context.Context, options *GetEmailVerificationOptions) *Iterator[Result] {
+	opts := GetEmailVerificationOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]Result, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.GetResults(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
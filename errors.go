@@ -0,0 +1,75 @@
+package mailersend
+
+import (
+	"errors"
+	"net"
+	"net/http"
+)
+
+// IsValidationError - reports whether err is a *ValidationError
+func IsValidationError(err error) bool {
+	var validationErr *ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// IsRateLimited - reports whether err was caused by hitting a rate limit, either returned
+// by the API as 429 Too Many Requests or reported by the client side rate limiter.
+func IsRateLimited(err error) bool {
+	var throttledErr *ThrottledError
+	if errors.As(err, &throttledErr) {
+		return true
+	}
+	return errorStatusCode(err) == http.StatusTooManyRequests
+}
+
+// IsNotFound - reports whether err was caused by a 404 Not Found response
+func IsNotFound(err error) bool {
+	return errorStatusCode(err) == http.StatusNotFound
+}
+
+// IsAuthError - reports whether err is an *AuthError
+func IsAuthError(err error) bool {
+	var authErr *AuthError
+	return errors.As(err, &authErr)
+}
+
+// errorStatusCode returns the status code of the response that caused err, or 0.
+func errorStatusCode(err error) int {
+	var errorResponse *ErrorResponse
+	var authErr *AuthError
+	var validationErr *ValidationError
+
+	switch {
+	case errors.As(err, &errorResponse):
+	case errors.As(err, &authErr):
+		errorResponse = (*ErrorResponse)(authErr)
+	case errors.As(err, &validationErr):
+		errorResponse = (*ErrorResponse)(validationErr)
+	default:
+		return 0
+	}
+
+	if errorResponse.Response == nil {
+		return 0
+	}
+	return errorResponse.Response.StatusCode
+}
+
+// IsRetryable - reports whether the request that failed with err may succeed if sent again later,
+// like after network errors, rate limiting, timeouts and server errors. Invalid messages, rejected
+// credentials, suppressed recipients and other errors are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsRateLimited(err) {
+		return true
+	}
+	if code := errorStatusCode(err); code != 0 {
+		return code == http.StatusRequestTimeout || code >= http.StatusInternalServerError
+	}
+
+	// Transport errors, including timeouts, are net.Errors.
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,96 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func errorClient(status int, body string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+			Request:    req,
+		}
+	})
+}
+
+func TestValidationErrorHasFieldErrors(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(errorClient(http.StatusUnprocessableEntity, `{
+		"message": "The to.0.email must be a valid email address.",
+		"errors": {
+			"to.0.email": ["The to.0.email must be a valid email address."],
+			"from.email": ["The from.email field is required."]
+		}
+	}`))
+
+	_, err := ms.Email.Send(context.TODO(), basicEmailNew())
+
+	var validationErr *mailersend.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.True(t, mailersend.IsValidationError(fmt.Errorf("wrapped: %w", err)))
+	assert.Equal(t, "The to.0.email must be a valid email address.", validationErr.Message)
+	assert.Equal(t, []string{"The from.email field is required."}, validationErr.Errors["from.email"])
+	assert.Equal(t, http.StatusUnprocessableEntity, validationErr.Response.StatusCode)
+	assert.Contains(t, err.Error(), "422")
+}
+
+func TestErrorHelpers(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ctx := context.TODO()
+
+	ms.SetClient(errorClient(http.StatusNotFound, `{"message": "Resource not found."}`))
+	_, _, err := ms.Domain.Get(ctx, "missing")
+	assert.True(t, mailersend.IsNotFound(err))
+	assert.False(t, mailersend.IsRateLimited(err))
+	assert.False(t, mailersend.IsValidationError(err))
+	assert.False(t, mailersend.IsRetryable(err))
+
+	ms.SetClient(errorClient(http.StatusTooManyRequests, `{"message": "Too Many Attempts."}`))
+	_, _, err = ms.Domain.Get(ctx, "domain-id")
+	assert.True(t, mailersend.IsRateLimited(err))
+	assert.False(t, mailersend.IsNotFound(err))
+	assert.True(t, mailersend.IsRetryable(err))
+
+	ms.SetClient(errorClient(http.StatusBadGateway, `{"message": "Bad gateway."}`))
+	_, _, err = ms.Domain.Get(ctx, "domain-id")
+	assert.True(t, mailersend.IsRetryable(err))
+
+	ms.SetClient(errorClient(http.StatusUnauthorized, `{"message": "Unauthenticated."}`))
+	_, _, err = ms.Domain.Get(ctx, "domain-id")
+	assert.True(t, mailersend.IsAuthError(err))
+	assert.False(t, mailersend.IsRetryable(err))
+
+	assert.True(t, mailersend.IsRateLimited(&mailersend.ThrottledError{}))
+	assert.False(t, mailersend.IsNotFound(errors.New("other")))
+	assert.True(t, mailersend.IsRetryable(&net.OpError{Op: "read", Err: errors.New("connection reset")}))
+	assert.True(t, mailersend.IsRetryable(context.DeadlineExceeded))
+	assert.False(t, mailersend.IsRetryable(errors.New("unknown")))
+	assert.False(t, mailersend.IsRetryable(mailersend.ErrMessageTooLarge))
+	assert.False(t, mailersend.IsRetryable(&mailersend.SuppressedRecipientsError{
+		Recipients: []mailersend.SuppressedRecipient{{Field: "to", Email: "bounced@client.com", List: mailersend.HardBounces}},
+	}))
+}
+
+func TestErrorResponseBodyIsDecoded(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(errorClient(http.StatusBadRequest, `{"message": "Bad request."}`))
+
+	root, res, err := ms.Domain.Get(context.TODO(), "domain-id")
+
+	var errorResponse *mailersend.ErrorResponse
+	assert.True(t, errors.As(err, &errorResponse))
+	assert.Equal(t, "Bad request.", errorResponse.Message)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Nil(t, root)
+}
@@ -0,0 +1,293 @@
+package mailersend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyTTL - how long sends are remembered when SetIdempotencyStore is given no ttl
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+const (
+	messageIDHeader    = "X-Message-Id"
+	smsMessageIDHeader = "X-SMS-Message-Id"
+)
+
+// ErrIdempotencyKeyInUse - returned by sends whose IdempotencyKey is reserved by a send that is still
+// running, or whose outcome is unknown because it timed out. The message may have been sent, so it
+// is not sent again until the reservation expires.
+var ErrIdempotencyKeyInUse = errors.New("mailersend: a send with this idempotency key is in progress or its outcome is unknown")
+
+// idempotencyPending is stored for a key while its send is in flight.
+const idempotencyPending = "pending"
+
+// IdempotencyStore - remembers the message id of sends by idempotency key,
+// so a send retried with the same key returns the original message id instead of sending again.
+type IdempotencyStore interface {
+	// SetIfAbsent stores the message id of key for ttl unless the key is already stored and not
+	// expired, in which case it returns the stored message id and stored is false. It must be atomic,
+	// as it reserves the key before sending.
+	SetIfAbsent(ctx context.Context, key string, messageID string, ttl time.Duration) (existing string, stored bool, err error)
+	// Set stores the message id of key for ttl, replacing the reservation.
+	Set(ctx context.Context, key string, messageID string, ttl time.Duration) error
+	// Delete removes key, so it can be sent again.
+	Delete(ctx context.Context, key string) error
+}
+
+// SetIdempotencyStore - Set the store that short-circuits sends of messages and sms
+// with an IdempotencyKey that was already sent within ttl, DefaultIdempotencyTTL when 0.
+// nil disables the store, the key is still sent in the Idempotency-Key header.
+func (ms *Mailersend) SetIdempotencyStore(store IdempotencyStore, ttl time.Duration) {
+	ms.update(func(state *clientState) {
+		state.idempotencyStore = store
+		state.idempotencyTTL = ttl
+	})
+}
+
+// sendOnce sends req with the idempotency key set on the message or sms, scope keeps the keys of
+// emails and sms apart. The key is reserved in the store before sending. When it was already sent,
+// a response carrying the original message id in header is returned without sending, and replayed
+// is true. The reservation is kept when the outcome of the send is unknown, and dropped when the
+// API rejected it.
+func (ms *Mailersend) sendOnce(ctx context.Context, operation string, scope string, key string, header string, req *http.Request, v interface{}) (res *Response, replayed bool, err error) {
+	if key == "" {
+		res, err = ms.do(ctx, operation, req, v)
+		return res, false, err
+	}
+
+	state := ms.stateOf(req)
+	store := state.idempotencyStore
+	if store == nil {
+		res, err = ms.do(WithIdempotencyKey(ctx, key), operation, req, v)
+		return res, false, err
+	}
+
+	ttl := state.idempotencyTTL
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	storeKey := scope + ":" + key
+
+	messageID, reserved, err := store.SetIfAbsent(ctx, storeKey, idempotencyPending, ttl)
+	if err != nil {
+		return nil, false, err
+	}
+	if !reserved {
+		if messageID == idempotencyPending {
+			return nil, false, ErrIdempotencyKeyInUse
+		}
+		return replayResponse(req, header, messageID), true, nil
+	}
+
+	res, err = ms.do(WithIdempotencyKey(ctx, key), operation, req, v)
+	if err != nil {
+		if notSent(err) {
+			_ = store.Delete(ctx, storeKey)
+		}
+		return res, false, err
+	}
+
+	// The message was sent, failing to remember it must not make the caller send it again.
+	_ = store.Set(ctx, storeKey, res.Header.Get(header), ttl)
+
+	return res, false, nil
+}
+
+// notSent reports whether err proves the message was not accepted: the API rejected it, the client
+// side rate limiter refused it, or no connection could be made.
+func notSent(err error) bool {
+	if IsRateLimited(err) {
+		return true
+	}
+	if code := errorStatusCode(err); code >= http.StatusBadRequest && code < http.StatusInternalServerError {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func replayResponse(req *http.Request, header string, messageID string) *Response {
+	resp := &http.Response{
+		Status:     http.StatusText(http.StatusAccepted),
+		StatusCode: http.StatusAccepted,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}
+	resp.Header.Set(header, messageID)
+
+	return newResponse(resp)
+}
+
+type idempotencyEntry struct {
+	MessageID string    `json:"message_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MemoryIdempotencyStore - IdempotencyStore kept in memory, shared by the clients of one process
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+	now     func() time.Time
+}
+
+// NewMemoryIdempotencyStore - creates an empty in-memory store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		entries: map[string]idempotencyEntry{},
+		now:     time.Now,
+	}
+}
+
+// SetIfAbsent - stores the message id of key for ttl unless it is already stored
+func (s *MemoryIdempotencyStore) SetIfAbsent(_ context.Context, key string, messageID string, ttl time.Duration) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	removeExpired(s.entries, now)
+	if entry, ok := s.entries[key]; ok {
+		return entry.MessageID, false, nil
+	}
+	s.entries[key] = idempotencyEntry{MessageID: messageID, ExpiresAt: now.Add(ttl)}
+
+	return "", true, nil
+}
+
+// Set - stores the message id of key for ttl, expired keys are dropped
+func (s *MemoryIdempotencyStore) Set(_ context.Context, key string, messageID string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	removeExpired(s.entries, now)
+	s.entries[key] = idempotencyEntry{MessageID: messageID, ExpiresAt: now.Add(ttl)}
+
+	return nil
+}
+
+// Delete - removes key
+func (s *MemoryIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+
+	return nil
+}
+
+// FileIdempotencyStore - IdempotencyStore kept in a JSON file, so sends are remembered across restarts.
+// The file is rewritten on every change, it suits a single process sending a moderate volume.
+// Keys are only reserved atomically within the process, do not share the file between processes.
+type FileIdempotencyStore struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]idempotencyEntry
+	now     func() time.Time
+}
+
+// NewFileIdempotencyStore - opens the store at path, which is created on the first Set
+func NewFileIdempotencyStore(path string) (*FileIdempotencyStore, error) {
+	s := &FileIdempotencyStore{
+		path:    path,
+		entries: map[string]idempotencyEntry{},
+		now:     time.Now,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.entries); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// SetIfAbsent - stores the message id of key for ttl unless it is already stored, and rewrites the file
+func (s *FileIdempotencyStore) SetIfAbsent(_ context.Context, key string, messageID string, ttl time.Duration) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	removeExpired(s.entries, now)
+	if entry, ok := s.entries[key]; ok {
+		return entry.MessageID, false, nil
+	}
+	s.entries[key] = idempotencyEntry{MessageID: messageID, ExpiresAt: now.Add(ttl)}
+
+	if err := s.save(); err != nil {
+		delete(s.entries, key)
+		return "", false, err
+	}
+
+	return "", true, nil
+}
+
+// Set - stores the message id of key for ttl and rewrites the file without the expired keys
+func (s *FileIdempotencyStore) Set(_ context.Context, key string, messageID string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	removeExpired(s.entries, now)
+	s.entries[key] = idempotencyEntry{MessageID: messageID, ExpiresAt: now.Add(ttl)}
+
+	return s.save()
+}
+
+// Delete - removes key and rewrites the file
+func (s *FileIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+
+	return s.save()
+}
+
+// save writes the entries to a temporary file and renames it over the store.
+func (s *FileIdempotencyStore) save() error {
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func removeExpired(entries map[string]idempotencyEntry, now time.Time) {
+	for key, entry := range entries {
+		if !now.Before(entry.ExpiresAt) {
+			delete(entries, key)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func idempotentClient(keys *[]string) *mailersend.Mailersend {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		*keys = append(*keys, req.Header.Get(mailersend.IdempotencyKeyHeader))
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       http.NoBody,
+			Header: http.Header{
+				"X-Message-Id":     []string{"message-id"},
+				"X-Sms-Message-Id": []string{"sms-id"},
+			},
+		}
+	}))
+
+	return ms
+}
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	var keys []string
+	ms := idempotentClient(&keys)
+
+	message := basicEmailNew()
+	message.IdempotencyKey = "order-1"
+
+	_, err := ms.Email.Send(context.TODO(), message)
+	assert.NoError(t, err)
+	_, err = ms.Email.Send(context.TODO(), message)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"order-1", "order-1"}, keys)
+}
+
+func TestIdempotencyStoreShortCircuitsDuplicates(t *testing.T) {
+	var keys []string
+	ms := idempotentClient(&keys)
+	ms.SetIdempotencyStore(mailersend.NewMemoryIdempotencyStore(), time.Hour)
+
+	message := basicEmailNew()
+	message.IdempotencyKey = "order-1"
+
+	first, err := ms.Email.SendWithResult(context.TODO(), message)
+	assert.NoError(t, err)
+	assert.False(t, first.Replayed)
+
+	second, err := ms.Email.SendWithResult(context.TODO(), message)
+	assert.NoError(t, err)
+	assert.True(t, second.Replayed)
+	assert.Equal(t, "message-id", second.MessageID)
+
+	res, err := ms.Email.Send(context.TODO(), message)
+	assert.NoError(t, err)
+	assert.Equal(t, "message-id", res.Header.Get("X-Message-Id"))
+
+	sms := ms.Sms.NewMessage()
+	sms.IdempotencyKey = "order-1"
+
+	res, err = ms.Sms.Send(context.TODO(), sms)
+	assert.NoError(t, err)
+	res, err = ms.Sms.Send(context.TODO(), sms)
+	assert.NoError(t, err)
+	assert.Equal(t, "sms-id", res.Header.Get("X-SMS-Message-Id"))
+
+	assert.Equal(t, []string{"order-1", "order-1"}, keys)
+}
+
+func TestIdempotencyKeyKeptAfterTimeout(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetIdempotencyStore(mailersend.NewMemoryIdempotencyStore(), time.Hour)
+
+	transport := &errorTransport{err: context.DeadlineExceeded}
+	ms.SetClient(&http.Client{Transport: transport})
+
+	message := basicEmailNew()
+	message.IdempotencyKey = "order-1"
+
+	_, err := ms.Email.Send(context.TODO(), message)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The first send may have reached the API, so the resend is refused instead of sent.
+	_, err = ms.Email.Send(context.TODO(), message)
+	assert.ErrorIs(t, err, mailersend.ErrIdempotencyKeyInUse)
+	assert.False(t, mailersend.IsRetryable(err))
+	assert.Equal(t, 1, transport.calls)
+}
+
+func TestIdempotencyKeyReleasedAfterRejection(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetIdempotencyStore(mailersend.NewMemoryIdempotencyStore(), time.Hour)
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		if calls == 1 {
+			return &http.Response{
+				StatusCode: http.StatusUnprocessableEntity,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "The from.email must be verified."}`)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       http.NoBody,
+			Header:     http.Header{"X-Message-Id": []string{"message-id"}},
+		}
+	}))
+
+	message := basicEmailNew()
+	message.IdempotencyKey = "order-1"
+
+	_, err := ms.Email.Send(context.TODO(), message)
+	assert.True(t, mailersend.IsValidationError(err))
+
+	result, err := ms.Email.SendWithResult(context.TODO(), message)
+	assert.NoError(t, err)
+	assert.False(t, result.Replayed)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyKeyReservedByConcurrentSend(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetIdempotencyStore(mailersend.NewMemoryIdempotencyStore(), time.Hour)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		close(started)
+		<-release
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       http.NoBody,
+			Header:     http.Header{"X-Message-Id": []string{"message-id"}},
+		}
+	}))
+
+	message := basicEmailNew()
+	message.IdempotencyKey = "order-1"
+
+	done := make(chan error)
+	go func() {
+		_, err := ms.Email.Send(context.TODO(), message)
+		done <- err
+	}()
+
+	<-started
+	_, err := ms.Email.Send(context.TODO(), message)
+	assert.ErrorIs(t, err, mailersend.ErrIdempotencyKeyInUse)
+
+	close(release)
+	assert.NoError(t, <-done)
+
+	result, err := ms.Email.SendWithResult(context.TODO(), message)
+	assert.NoError(t, err)
+	assert.True(t, result.Replayed)
+}
+
+func TestFileIdempotencyStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sends.json")
+	ctx := context.TODO()
+
+	store, err := mailersend.NewFileIdempotencyStore(path)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Set(ctx, "email:order-1", "message-id", time.Hour))
+	assert.NoError(t, store.Set(ctx, "email:order-2", "expired-id", -time.Second))
+	assert.NoError(t, store.Set(ctx, "email:order-3", "deleted-id", time.Hour))
+	assert.NoError(t, store.Delete(ctx, "email:order-3"))
+
+	reopened, err := mailersend.NewFileIdempotencyStore(path)
+	assert.NoError(t, err)
+
+	id, stored, err := reopened.SetIfAbsent(ctx, "email:order-1", "other-id", time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, stored)
+	assert.Equal(t, "message-id", id)
+
+	for _, key := range []string{"email:order-2", "email:order-3"} {
+		_, stored, err = reopened.SetIfAbsent(ctx, key, "new-id", time.Hour)
+		assert.NoError(t, err)
+		assert.True(t, stored)
+	}
+}
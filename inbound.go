@@ -10,6 +10,7 @@ const inboundBasePath = "/inbound"
 
 type InboundService interface {
 	List(ctx context.Context, options *ListInboundOptions) (*InboundRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListInboundOptions) *Iterator[Inbound]
 	Get(ctx context.Context, inboundID string) (*SingleInboundRoot, *Response, error)
 	Create(ctx context.Context, options *CreateInboundOptions) (*SingleInboundRoot, *Response, error)
 	Update(ctx context.Context, inboundID string, options *UpdateInboundOptions) (*SingleInboundRoot, *Response, error)
@@ -109,7 +110,7 @@ func (s *inboundService) List(ctx context.Context, options *ListInboundOptions)
 	}
 
 	root := new(InboundRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Inbound.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -117,6 +118,24 @@ func (s *inboundService) List(ctx context.Context, options *ListInboundOptions)
 	return root, res, nil
 }
 
+func (s *inboundService) ListAll(ctx context.Context, options *ListInboundOptions) *Iterator[Inbound] {
+	opts := ListInboundOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]Inbound, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *inboundService) Get(ctx context.Context, inboundID string) (*SingleInboundRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", inboundBasePath, inboundID)
 
@@ -126,7 +145,7 @@ func (s *inboundService) Get(ctx context.Context, inboundID string) (*SingleInbo
 	}
 
 	root := new(SingleInboundRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Inbound.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -141,7 +160,7 @@ func (s *inboundService) Create(ctx context.Context, options *CreateInboundOptio
 	}
 
 	root := new(SingleInboundRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Inbound.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -158,7 +177,7 @@ func (s *inboundService) Update(ctx context.Context, inboundID string, options *
 	}
 
 	root := new(SingleInboundRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Inbound.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -174,5 +193,5 @@ func (s *inboundService) Delete(ctx context.Context, inboundID string) (*Respons
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Inbound.Delete", req, nil)
 }
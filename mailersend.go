@@ -9,17 +9,22 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 const APIBase string = "https://api.mailersend.com/v1"
 
-// Mailersend - base mailersend api client
+// Mailersend - base mailersend api client.
+// It is safe for concurrent use, the setters replace the configuration atomically and
+// every request, including its http client, retries, rate limiting and middleware, uses
+// the configuration current when it was built.
 type Mailersend struct {
-	apiBase string
-	apiKey  string
-	client  *http.Client
+	mu    sync.Mutex   // serializes changes to state
+	state atomic.Value // *clientState, never modified once stored
 
 	common service // Reuse a single struct.
 
@@ -52,6 +57,44 @@ type Mailersend struct {
 	DmarcMonitoring   DmarcMonitoringService
 }
 
+// clientState is the configuration of a Mailersend client. It is copied on every change.
+type clientState struct {
+	apiBase     string
+	apiKey      string
+	userAgent   string
+	client      *http.Client
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	validation  bool
+	middleware  []Middleware
+
+	idempotencyStore IdempotencyStore
+	idempotencyTTL   time.Duration
+	suppressionGuard *SuppressionGuard
+
+	defaultFrom     *From
+	defaultSettings *Settings
+}
+
+// snapshot returns the current configuration, which must not be modified.
+func (ms *Mailersend) snapshot() *clientState {
+	if state, ok := ms.state.Load().(*clientState); ok {
+		return state
+	}
+
+	return &clientState{apiBase: APIBase, userAgent: UserAgent, client: http.DefaultClient}
+}
+
+// update applies change to a copy of the configuration and makes the copy current.
+func (ms *Mailersend) update(change func(state *clientState)) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	state := *ms.snapshot()
+	change(&state)
+	ms.state.Store(&state)
+}
+
 type service struct {
 	client *Mailersend
 }
@@ -64,11 +107,15 @@ type Response struct {
 }
 
 type ErrorResponse struct {
-	Response *http.Response // HTTP response that caused this error
-	Message  string         `json:"message"` // error message
+	Response *http.Response      // HTTP response that caused this error
+	Message  string              `json:"message"`          // error message
+	Errors   map[string][]string `json:"errors,omitempty"` // per-field error messages
 }
 
 func (r *ErrorResponse) Error() string {
+	if r.Response == nil || r.Response.Request == nil {
+		return r.Message
+	}
 	return fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, r.Response.Request.URL,
 		r.Response.StatusCode, r.Message)
@@ -79,6 +126,12 @@ type AuthError ErrorResponse
 
 func (r *AuthError) Error() string { return (*ErrorResponse)(r).Error() }
 
+// ValidationError occurs when the API rejects a request with 422 Unprocessable Entity.
+// Errors holds the messages for each invalid field, keyed like "to.0.email".
+type ValidationError ErrorResponse
+
+func (r *ValidationError) Error() string { return (*ErrorResponse)(r).Error() }
+
 // Meta - used for api responses
 type Meta struct {
 	CurrentPage json.Number `json:"current_page"`
@@ -104,13 +157,27 @@ type Filter struct {
 }
 
 // NewMailersend - creates a new client instance.
-func NewMailersend(apiKey string) *Mailersend {
-	ms := &Mailersend{
-		apiBase: APIBase,
-		apiKey:  apiKey,
-		client:  http.DefaultClient,
+func NewMailersend(apiKey string, opts ...Option) *Mailersend {
+	state := &clientState{
+		apiBase:   APIBase,
+		apiKey:    apiKey,
+		userAgent: UserAgent,
+		client:    http.DefaultClient,
 	}
 
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	options.apply(state)
+
+	return newMailersend(state)
+}
+
+func newMailersend(state *clientState) *Mailersend {
+	ms := &Mailersend{}
+	ms.state.Store(state)
+
 	ms.common.client = ms
 	ms.Activity = &activityService{&ms.common}
 	ms.Analytics = &analyticsService{&ms.common}
@@ -144,26 +211,66 @@ func NewMailersend(apiKey string) *Mailersend {
 
 // APIKey - Get api key after it has been created
 func (ms *Mailersend) APIKey() string {
-	return ms.apiKey
+	return ms.snapshot().apiKey
 }
 
 // Client - Get the current client
 func (ms *Mailersend) Client() *http.Client {
-	return ms.client
+	return ms.snapshot().client
 }
 
 // SetClient - Set the client if you want more control over the client implementation
 func (ms *Mailersend) SetClient(c *http.Client) {
-	ms.client = c
+	ms.update(func(state *clientState) {
+		state.client = c
+	})
+}
+
+// SetRetryPolicy - Set the policy used to retry failed requests, nil disables retries
+func (ms *Mailersend) SetRetryPolicy(policy *RetryPolicy) {
+	ms.update(func(state *clientState) {
+		state.retryPolicy = policy
+	})
+}
+
+// SetRateLimiter - Set the limiter used to throttle outgoing requests, nil disables throttling
+func (ms *Mailersend) SetRateLimiter(limiter *RateLimiter) {
+	ms.update(func(state *clientState) {
+		state.rateLimiter = limiter
+	})
+}
+
+// SetValidation - Validate messages with Message.Validate before sending them
+func (ms *Mailersend) SetValidation(enabled bool) {
+	ms.update(func(state *clientState) {
+		state.validation = enabled
+	})
 }
 
-// SetAPIKey - Set the client api key
+// SetAPIKey - Set the client api key, requests already built keep the previous key
 func (ms *Mailersend) SetAPIKey(apikey string) {
-	ms.apiKey = apikey
+	ms.update(func(state *clientState) {
+		state.apiKey = apikey
+	})
+}
+
+// WithAPIKey - returns a copy of the client using apiKey, the original client is not modified
+func (ms *Mailersend) WithAPIKey(apiKey string) *Mailersend {
+	state := *ms.snapshot()
+	state.apiKey = apiKey
+
+	return newMailersend(&state)
 }
 
 func (ms *Mailersend) newRequest(method, path string, body interface{}) (*http.Request, error) {
-	reqURL := fmt.Sprintf("%s%s", ms.apiBase, path)
+	return ms.snapshot().newRequest(method, path, body)
+}
+
+type clientStateKey struct{}
+
+// newRequest builds a request that is sent with state by Mailersend.do.
+func (state *clientState) newRequest(method, path string, body interface{}) (*http.Request, error) {
+	reqURL := fmt.Sprintf("%s%s", state.apiBase, path)
 	reqBodyBytes := new(bytes.Buffer)
 
 	if method == http.MethodPost ||
@@ -177,22 +284,37 @@ func (ms *Mailersend) newRequest(method, path string, body interface{}) (*http.R
 		reqURL, _ = addOptions(reqURL, body)
 	}
 
-	req, err := http.NewRequest(method, reqURL, reqBodyBytes)
+	ctx := context.WithValue(context.Background(), clientStateKey{}, state)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBodyBytes)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+ms.apiKey)
+	req.Header.Add("Authorization", "Bearer "+state.apiKey)
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "Mailersend-Client-Golang-v1")
+	req.Header.Set("User-Agent", state.userAgent)
 
 	return req, nil
 }
 
-func (ms *Mailersend) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	req = req.WithContext(ctx)
-	resp, err := ms.client.Do(req)
+// stateOf returns the configuration req was built with.
+func (ms *Mailersend) stateOf(req *http.Request) *clientState {
+	if state, ok := req.Context().Value(clientStateKey{}).(*clientState); ok {
+		return state
+	}
+	return ms.snapshot()
+}
+
+// do sends req for the client method named by operation, like "Domain.Verify".
+func (ms *Mailersend) do(ctx context.Context, operation string, req *http.Request, v interface{}) (*Response, error) {
+	state := ms.stateOf(req)
+	req = req.WithContext(withCallInfo(ctx, &callInfo{operation: operation}))
+	if key := idempotencyKeyFromContext(ctx); key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	resp, err := state.doer().Do(req)
 	if err != nil {
 		select {
 		case <-ctx.Done():
@@ -202,25 +324,23 @@ func (ms *Mailersend) do(ctx context.Context, req *http.Request, v interface{})
 		return nil, err
 	}
 
-	if v != nil {
-		err = json.NewDecoder(resp.Body).Decode(v)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	response := newResponse(resp)
 
 	err = CheckResponse(resp)
 	if err != nil {
+		resp.Body.Close()
+		return response, err
+	}
+
+	if v != nil {
 		defer resp.Body.Close()
-		_, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return response, readErr
+		err = json.NewDecoder(resp.Body).Decode(v)
+		if err != nil && err != io.EOF {
+			return response, err
 		}
 	}
 
-	return response, err
+	return response, nil
 }
 
 // newResponse creates a new Response for the provided http.Response.
@@ -250,6 +370,8 @@ func CheckResponse(r *http.Response) error {
 	switch {
 	case r.StatusCode == http.StatusUnauthorized:
 		return (*AuthError)(errorResponse)
+	case r.StatusCode == http.StatusUnprocessableEntity:
+		return (*ValidationError)(errorResponse)
 	default:
 		return errorResponse
 	}
@@ -0,0 +1,249 @@
+package mailersendtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mailersend/mailersend-go"
+)
+
+// AddDomain - adds a verified domain and returns it
+func (s *Server) AddDomain(name string) mailersend.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domain := s.newDomain(name)
+	verifyDomain(domain)
+
+	return *domain
+}
+
+func (s *Server) newDomain(name string) *mailersend.Domain {
+	createdAt := now().Format(timeFormat)
+	domain := &mailersend.Domain{
+		ID:                newID(),
+		Name:              name,
+		IsTrackingAllowed: true,
+		DomainSettings: mailersend.DomainSettings{
+			TrackClicks:      true,
+			TrackOpens:       true,
+			TrackUnsubscribe: true,
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	s.domains = append(s.domains, domain)
+
+	return domain
+}
+
+func verifyDomain(domain *mailersend.Domain) {
+	domain.Dkim = true
+	domain.Spf = true
+	domain.Tracking = true
+	domain.IsVerified = true
+	domain.IsCnameVerified = true
+	domain.IsDNSActive = true
+	domain.IsCnameActive = true
+	domain.UpdatedAt = now().Format(timeFormat)
+}
+
+func (s *Server) findDomain(id string) (int, *mailersend.Domain) {
+	for i, domain := range s.domains {
+		if domain.ID == id {
+			return i, domain
+		}
+	}
+	return -1, nil
+}
+
+func (s *Server) handleDomains(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			verified := r.URL.Query().Get("verified")
+
+			domains := make([]mailersend.Domain, 0, len(s.domains))
+			for _, domain := range s.domains {
+				if verified != "" && (verified == "true") != domain.IsVerified {
+					continue
+				}
+				domains = append(domains, *domain)
+			}
+
+			page, links, meta := paginate(r, domains)
+			writeJSON(w, http.StatusOK, mailersend.DomainRoot{Data: page, Links: links, Meta: meta})
+		case http.MethodPost:
+			var options mailersend.CreateDomainOptions
+			if !decode(w, r, &options) {
+				return
+			}
+			if options.Name == "" {
+				validationFailed(w, map[string][]string{"name": {"The name field is required."}})
+				return
+			}
+			for _, domain := range s.domains {
+				if strings.EqualFold(domain.Name, options.Name) {
+					validationFailed(w, map[string][]string{"name": {"The name has already been taken."}})
+					return
+				}
+			}
+
+			domain := s.newDomain(options.Name)
+			if options.CustomTrackingSubdomain != "" {
+				domain.DomainSettings.CustomTrackingSubdomain = options.CustomTrackingSubdomain
+			}
+
+			writeJSON(w, http.StatusCreated, mailersend.SingleDomainRoot{Data: *domain})
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	i, domain := s.findDomain(segments[0])
+	if domain == nil {
+		notFound(w)
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, mailersend.SingleDomainRoot{Data: *domain})
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.domains = append(s.domains[:i], s.domains[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 2 && segments[1] == "settings" && r.Method == http.MethodPut:
+		var options mailersend.DomainSettingOptions
+		if !decode(w, r, &options) {
+			return
+		}
+		updateDomainSettings(&domain.DomainSettings, options)
+		domain.UpdatedAt = now().Format(timeFormat)
+
+		writeJSON(w, http.StatusOK, mailersend.SingleDomainRoot{Data: *domain})
+	case len(segments) == 2 && segments[1] == "dns-records" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, mailersend.DnsRoot{Data: dnsRecords(domain)})
+	case len(segments) == 2 && segments[1] == "verify" && r.Method == http.MethodGet:
+		verifyDomain(domain)
+		writeJSON(w, http.StatusOK, mailersend.VerifyRoot{
+			Message: "The domain is verified.",
+			Data: mailersend.Verify{
+				Dkim:     true,
+				Spf:      true,
+				Mx:       true,
+				Tracking: true,
+				Cname:    true,
+				RpCname:  true,
+			},
+		})
+	case len(segments) == 2 && segments[1] == "recipients" && r.Method == http.MethodGet:
+		page, links, meta := paginate(r, s.domainRecipients(domain))
+		writeJSON(w, http.StatusOK, mailersend.DomainRecipientRoot{Data: page, Links: links, Meta: meta})
+	case len(segments) <= 2:
+		methodNotAllowed(w)
+	default:
+		notFound(w)
+	}
+}
+
+func updateDomainSettings(settings *mailersend.DomainSettings, options mailersend.DomainSettingOptions) {
+	set := func(field *bool, value *bool) {
+		if value != nil {
+			*field = *value
+		}
+	}
+
+	set(&settings.SendPaused, options.SendPaused)
+	set(&settings.TrackClicks, options.TrackClicks)
+	set(&settings.TrackOpens, options.TrackOpens)
+	set(&settings.TrackUnsubscribe, options.TrackUnsubscribe)
+	set(&settings.TrackContent, options.TrackContent)
+	set(&settings.CustomTrackingEnabled, options.CustomTrackingEnabled)
+	set(&settings.IgnoreDuplicatedRecipients, options.IgnoreDuplicatedRecipients)
+	set(&settings.PrecedenceBulk, options.PrecedenceBulk)
+
+	if options.TrackUnsubscribeHTML != "" {
+		settings.TrackUnsubscribeHTML = options.TrackUnsubscribeHTML
+	}
+	if options.TrackUnsubscribePlain != "" {
+		settings.TrackUnsubscribePlain = options.TrackUnsubscribePlain
+	}
+	if options.CustomTrackingSubdomain != "" {
+		settings.CustomTrackingSubdomain = options.CustomTrackingSubdomain
+	}
+}
+
+func dnsRecords(domain *mailersend.Domain) mailersend.Dns {
+	return mailersend.Dns{
+		ID: domain.ID,
+		Spf: mailersend.Spf{
+			Hostname: domain.Name,
+			Type:     "TXT",
+			Value:    "v=spf1 include:_spf.mailersend.net ~all",
+		},
+		Dkim: mailersend.Dkim{
+			Hostname: "mlsend2._domainkey." + domain.Name,
+			Type:     "CNAME",
+			Value:    "mlsend2._domainkey.mailersend.net",
+		},
+		ReturnPath: mailersend.ReturnPath{
+			Hostname: "mta." + domain.Name,
+			Type:     "CNAME",
+			Value:    "mailersend.net",
+		},
+		CustomTracking: mailersend.CustomTracking{
+			Hostname: "links." + domain.Name,
+			Type:     "CNAME",
+			Value:    "links.mailersend.net",
+		},
+		InboundRouting: mailersend.InboundRouting{
+			Hostname: "inbound." + domain.Name,
+			Type:     "MX",
+			Value:    "inbound.mailersend.net",
+			Priority: "10",
+		},
+	}
+}
+
+// domainRecipients returns every distinct recipient that was sent an email from the domain.
+func (s *Server) domainRecipients(domain *mailersend.Domain) []mailersend.DomainRecipient {
+	seen := map[string]bool{}
+	recipients := []mailersend.DomainRecipient{}
+
+	for _, sent := range s.sent {
+		if s.domainOf(sent.Message.From.Email).ID != domain.ID {
+			continue
+		}
+
+		for _, recipient := range sent.Message.Recipients {
+			email := strings.ToLower(recipient.Email)
+			if seen[email] {
+				continue
+			}
+			seen[email] = true
+
+			createdAt := sent.SentAt.Format(timeFormat)
+			recipients = append(recipients, mailersend.DomainRecipient{
+				ID:        s.recipientID(email),
+				Email:     recipient.Email,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			})
+		}
+	}
+
+	return recipients
+}
+
+// recipientID returns a stable id for the recipient email.
+func (s *Server) recipientID(email string) string {
+	if s.recipientIDs == nil {
+		s.recipientIDs = make(map[string]string)
+	}
+	if _, ok := s.recipientIDs[email]; !ok {
+		s.recipientIDs[email] = newID()
+	}
+
+	return s.recipientIDs[email]
+}
@@ -0,0 +1,365 @@
+package mailersendtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+)
+
+// message is the fake's record of an accepted email, as returned by the messages endpoints.
+type message struct {
+	id        string
+	domain    mailersend.Domain
+	emails    []mailersend.Email
+	createdAt time.Time
+}
+
+// bulkEmail is a bulk email job, advanced one state on every status request.
+type bulkEmail struct {
+	data     mailersend.BulkEmailData
+	messages []mailersend.Message
+}
+
+func (s *Server) handleEmail(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 0 {
+		notFound(w)
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	var msg mailersend.Message
+	if !decode(w, r, &msg) {
+		return
+	}
+	if errors := validateMessage(msg); len(errors) > 0 {
+		validationFailed(w, errors)
+		return
+	}
+
+	warnings, allowed := s.filterSuppressed(msg)
+	if len(allowed) == 0 {
+		writeJSON(w, http.StatusAccepted, mailersend.SendResult{
+			Message:  "There are some warnings for your request.",
+			Warnings: warnings,
+		})
+		return
+	}
+
+	msg.Recipients = allowed
+	id := s.send(msg, "")
+
+	w.Header().Set("X-Message-Id", id)
+	if len(warnings) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, mailersend.SendResult{
+		Message:  "There are some warnings for your request.",
+		Warnings: warnings,
+	})
+}
+
+func (s *Server) handleBulkEmail(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var messages []mailersend.Message
+		if !decode(w, r, &messages) {
+			return
+		}
+
+		var errors mailersend.BulkEmailValidationErrors
+		for i, msg := range messages {
+			if fields := validateMessage(msg); len(fields) > 0 {
+				errors = append(errors, mailersend.BulkEmailValidationError{MessageIndex: i, Errors: fields})
+			}
+		}
+
+		recipients := 0
+		for _, msg := range messages {
+			recipients += len(msg.Recipients)
+		}
+
+		createdAt := now()
+		job := &bulkEmail{
+			data: mailersend.BulkEmailData{
+				ID:                    newID(),
+				State:                 mailersend.BulkEmailStateQueued,
+				TotalRecipientsCount:  recipients,
+				ValidationErrorsCount: len(errors),
+				ValidationErrors:      errors,
+				MessagesID:            []string{},
+				CreatedAt:             createdAt,
+				UpdatedAt:             createdAt,
+			},
+			messages: messages,
+		}
+		s.bulkEmails[job.data.ID] = job
+
+		writeJSON(w, http.StatusAccepted, mailersend.BulkEmailResponse{
+			Message:     "The bulk email is being processed.",
+			BulkEmailID: job.data.ID,
+		})
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		job, ok := s.bulkEmails[segments[0]]
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		s.advanceBulkEmail(job)
+
+		writeJSON(w, http.StatusOK, mailersend.BulkEmailRoot{Data: job.data})
+	case len(segments) <= 1:
+		methodNotAllowed(w)
+	default:
+		notFound(w)
+	}
+}
+
+// CompleteBulkEmails - processes every pending bulk email job immediately
+func (s *Server) CompleteBulkEmails() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.bulkEmails {
+		for job.data.State != mailersend.BulkEmailStateCompleted {
+			s.advanceBulkEmail(job)
+		}
+	}
+}
+
+func (s *Server) advanceBulkEmail(job *bulkEmail) {
+	switch job.data.State {
+	case mailersend.BulkEmailStateQueued:
+		job.data.State = mailersend.BulkEmailStateProcessing
+	case mailersend.BulkEmailStateProcessing:
+		var suppressed mailersend.BulkEmailSuppressedRecipients
+		for i, msg := range job.messages {
+			if len(validateMessage(msg)) > 0 {
+				continue
+			}
+
+			warnings, allowed := s.filterSuppressed(msg)
+			for _, warning := range warnings {
+				for _, recipient := range warning.Recipients {
+					suppressed = append(suppressed, mailersend.BulkEmailSuppressedRecipient{
+						MessageIndex: i,
+						Field:        "to",
+						Email:        recipient.Email,
+						Reasons:      recipient.Reasons,
+					})
+				}
+			}
+			if len(allowed) == 0 {
+				continue
+			}
+
+			msg.Recipients = allowed
+			job.data.MessagesID = append(job.data.MessagesID, s.send(msg, job.data.ID))
+			job.messages[i] = msg
+		}
+
+		job.data.State = mailersend.BulkEmailStateCompleted
+		job.data.SuppressedRecipientsCount = len(suppressed)
+		job.data.SuppressedRecipients = suppressed
+	default:
+		return
+	}
+
+	job.data.UpdatedAt = now()
+}
+
+// send records an accepted email and returns its message id.
+func (s *Server) send(msg mailersend.Message, bulkEmailID string) string {
+	sentAt := now()
+	record := &message{
+		id:        newID(),
+		domain:    s.domainOf(msg.From.Email),
+		createdAt: sentAt,
+	}
+
+	for range msg.Recipients {
+		record.emails = append(record.emails, mailersend.Email{
+			ID:        newID(),
+			From:      msg.From.Email,
+			Subject:   msg.Subject,
+			Text:      msg.Text,
+			HTML:      msg.HTML,
+			Tags:      msg.Tags,
+			Status:    "queued",
+			CreatedAt: sentAt,
+			UpdatedAt: sentAt,
+		})
+	}
+
+	s.messages = append(s.messages, record)
+	s.sent = append(s.sent, SentEmail{
+		MessageID:   record.id,
+		BulkEmailID: bulkEmailID,
+		Message:     msg,
+		SentAt:      sentAt,
+	})
+
+	return record.id
+}
+
+// filterSuppressed splits the recipients of msg into warnings for the suppressed ones and the ones to send to.
+func (s *Server) filterSuppressed(msg mailersend.Message) ([]mailersend.SendWarning, []mailersend.Recipient) {
+	var (
+		suppressed []mailersend.SendWarningRecipient
+		allowed    []mailersend.Recipient
+	)
+
+	domainID := s.domainOf(msg.From.Email).ID
+	for _, recipient := range msg.Recipients {
+		reasons := s.suppressedFor(domainID, recipient.Email)
+		if len(reasons) == 0 {
+			allowed = append(allowed, recipient)
+			continue
+		}
+
+		suppressed = append(suppressed, mailersend.SendWarningRecipient{
+			Email:   recipient.Email,
+			Name:    recipient.Name,
+			Reasons: reasons,
+		})
+	}
+
+	if len(suppressed) == 0 {
+		return nil, allowed
+	}
+
+	warning := mailersend.SendWarning{
+		Type:       mailersend.SendWarningSomeSuppressed,
+		Warning:    "Some of the recipients have been suppressed.",
+		Recipients: suppressed,
+	}
+	if len(allowed) == 0 {
+		warning.Type = mailersend.SendWarningAllSuppressed
+		warning.Warning = "None of the recipients were sent this email because they are all suppressed."
+	}
+
+	return []mailersend.SendWarning{warning}, allowed
+}
+
+// suppressedFor returns why email is suppressed for the sending domain, entries without a domain apply to all.
+func (s *Server) suppressedFor(domainID string, email string) []string {
+	email = strings.ToLower(email)
+
+	var reasons []string
+	for _, suppressionType := range suppressionTypes {
+		for _, entry := range s.suppressions[suppressionType] {
+			if entry.domainID != "" && domainID != "" && entry.domainID != domainID {
+				continue
+			}
+			value := strings.ToLower(entry.value)
+			if value == email || (entry.pattern && mailersend.MatchBlocklistPattern(value, email)) {
+				reasons = append(reasons, suppressionReasons[suppressionType])
+				break
+			}
+		}
+	}
+
+	return reasons
+}
+
+func (s *Server) domainOf(email string) mailersend.Domain {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return mailersend.Domain{}
+	}
+
+	name := strings.ToLower(email[at+1:])
+	for _, domain := range s.domains {
+		if strings.ToLower(domain.Name) == name {
+			return *domain
+		}
+	}
+
+	return mailersend.Domain{}
+}
+
+// validateMessage checks the fields the API requires.
+func validateMessage(msg mailersend.Message) map[string][]string {
+	errors := map[string][]string{}
+	add := func(key, message string) {
+		errors[key] = append(errors[key], message)
+	}
+
+	if msg.From.Email == "" && msg.TemplateID == "" {
+		add("from.email", "The from.email field is required.")
+	} else if msg.From.Email != "" && !validEmail(msg.From.Email) {
+		add("from.email", "The from.email must be a valid email address.")
+	}
+
+	if len(msg.Recipients) == 0 {
+		add("to", "The to field is required.")
+	}
+	for i, recipient := range msg.Recipients {
+		if !validEmail(recipient.Email) {
+			add(fmt.Sprintf("to.%d.email", i), fmt.Sprintf("The to.%d.email must be a valid email address.", i))
+		}
+	}
+
+	if msg.TemplateID == "" {
+		if msg.Subject == "" {
+			add("subject", "The subject field is required when template id is not present.")
+		}
+		if msg.Text == "" && msg.HTML == "" {
+			add("text", "The text field is required when neither html nor template id is present.")
+		}
+	}
+
+	return errors
+}
+
+func validEmail(email string) bool {
+	at := strings.LastIndex(email, "@")
+	return at > 0 && at < len(email)-1 && !strings.ContainsAny(email, " \t\n")
+}
+
+func (s *Server) handleMessages(w http.ResponseWriter, r *http.Request, segments []string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	switch len(segments) {
+	case 0:
+		// newest first, like the API
+		data := make([]mailersend.MessageData, 0, len(s.messages))
+		for i := len(s.messages) - 1; i >= 0; i-- {
+			data = append(data, mailersend.MessageData{
+				ID:        s.messages[i].id,
+				CreatedAt: s.messages[i].createdAt,
+				UpdatedAt: s.messages[i].createdAt,
+			})
+		}
+
+		page, links, meta := paginate(r, data)
+		writeJSON(w, http.StatusOK, mailersend.MessageRoot{Data: page, Links: links, Meta: meta})
+	case 1:
+		for _, msg := range s.messages {
+			if msg.id == segments[0] {
+				writeJSON(w, http.StatusOK, mailersend.SingleMessageRoot{Data: mailersend.SingleMessage{
+					ID:        msg.id,
+					Emails:    msg.emails,
+					Domain:    msg.domain,
+					CreatedAt: msg.createdAt,
+					UpdatedAt: msg.createdAt,
+				}})
+				return
+			}
+		}
+		notFound(w)
+	default:
+		notFound(w)
+	}
+}
@@ -0,0 +1,258 @@
+// Package mailersendtest provides an in-memory fake of the MailerSend API for integration tests.
+//
+//	srv := mailersendtest.NewServer()
+//	defer srv.Close()
+//
+//	ms := srv.NewMailersend()
+//	_, _ = ms.Email.Send(ctx, message)
+//
+//	sent := srv.SentEmails()
+//
+// The fake implements the email, bulk email, domains, messages, templates, webhooks and
+// suppressions endpoints and keeps their state in memory.
+package mailersendtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+)
+
+const timeFormat = "2006-01-02T15:04:05.000000Z"
+
+// Server - fake MailerSend API backed by an httptest.Server
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	sent         []SentEmail
+	messages     []*message
+	bulkEmails   map[string]*bulkEmail
+	domains      []*mailersend.Domain
+	templates    []*mailersend.SingleTemplate
+	webhooks     []*mailersend.Webhook
+	suppressions map[mailersend.SuppressionType][]*suppression
+	recipientIDs map[string]string
+}
+
+// SentEmail - an email accepted by the fake, either directly or through a bulk email
+type SentEmail struct {
+	MessageID   string
+	BulkEmailID string
+	Message     mailersend.Message
+	SentAt      time.Time
+}
+
+// NewServer - starts a new fake MailerSend API, call Close when done
+func NewServer() *Server {
+	s := &Server{
+		bulkEmails:   make(map[string]*bulkEmail),
+		suppressions: make(map[mailersend.SuppressionType][]*suppression),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client - returns an http.Client that sends requests for the MailerSend API to the fake
+func (s *Server) Client() *http.Client {
+	target, _ := url.Parse(s.URL)
+
+	return &http.Client{
+		Transport: &rewriteTransport{target: target, next: s.Server.Client().Transport},
+	}
+}
+
+// NewMailersend - returns a client that talks to the fake
+func (s *Server) NewMailersend() *mailersend.Mailersend {
+	return mailersend.NewMailersend("mailersendtest-api-key",
+		mailersend.WithBaseURL(s.URL+"/v1"),
+		mailersend.WithHTTPClient(s.Server.Client()),
+	)
+}
+
+// SentEmails - returns every email accepted so far, in order
+func (s *Server) SentEmails() []SentEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sent := make([]SentEmail, len(s.sent))
+	copy(sent, s.sent)
+
+	return sent
+}
+
+// Reset - forgets all state
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = nil
+	s.messages = nil
+	s.bulkEmails = make(map[string]*bulkEmail)
+	s.domains = nil
+	s.templates = nil
+	s.webhooks = nil
+	s.suppressions = make(map[mailersend.SuppressionType][]*suppression)
+	s.recipientIDs = nil
+}
+
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+
+	return t.next.RoundTrip(req)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") ||
+		strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") == "" {
+		writeError(w, http.StatusUnauthorized, "Unauthenticated.", nil)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1"), "/")
+	segments := strings.Split(path, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch segments[0] {
+	case "email":
+		s.handleEmail(w, r, segments[1:])
+	case "bulk-email":
+		s.handleBulkEmail(w, r, segments[1:])
+	case "domains":
+		s.handleDomains(w, r, segments[1:])
+	case "messages":
+		s.handleMessages(w, r, segments[1:])
+	case "templates":
+		s.handleTemplates(w, r, segments[1:])
+	case "webhooks":
+		s.handleWebhooks(w, r, segments[1:])
+	case "suppressions":
+		s.handleSuppressions(w, r, segments[1:])
+	default:
+		notFound(w)
+	}
+}
+
+func newID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "The request body is not valid JSON.", nil)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string, errors map[string][]string) {
+	writeJSON(w, status, struct {
+		Message string              `json:"message"`
+		Errors  map[string][]string `json:"errors,omitempty"`
+	}{message, errors})
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Resource not found.", nil)
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "The method is not supported for this route.", nil)
+}
+
+func validationFailed(w http.ResponseWriter, errors map[string][]string) {
+	message := "The given data was invalid."
+	for _, messages := range errors {
+		message = messages[0]
+		break
+	}
+	writeError(w, http.StatusUnprocessableEntity, message, errors)
+}
+
+// paginate returns the requested page of items along with the links and meta of the response.
+func paginate[T any](r *http.Request, items []T) ([]T, mailersend.Links, mailersend.Meta) {
+	query := r.URL.Query()
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit < 1 {
+		limit = 25
+	}
+
+	from := (page - 1) * limit
+	if from > len(items) {
+		from = len(items)
+	}
+	to := from + limit
+	if to > len(items) {
+		to = len(items)
+	}
+
+	lastPage := (len(items) + limit - 1) / limit
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	pageURL := func(p int) string {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(p))
+		return fmt.Sprintf("%s?%s", r.URL.Path, q.Encode())
+	}
+
+	links := mailersend.Links{First: pageURL(1), Last: pageURL(lastPage)}
+	if page > 1 {
+		links.Prev = pageURL(page - 1)
+	}
+	if page < lastPage {
+		links.Next = pageURL(page + 1)
+	}
+
+	meta := mailersend.Meta{
+		CurrentPage: json.Number(strconv.Itoa(page)),
+		From:        json.Number(strconv.Itoa(from + 1)),
+		Path:        r.URL.Path,
+		PerPage:     json.Number(strconv.Itoa(limit)),
+		To:          json.Number(strconv.Itoa(to)),
+	}
+
+	return items[from:to], links, meta
+}
@@ -0,0 +1,302 @@
+package mailersendtest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/mailersend/mailersend-go/mailersendtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMessage(to ...string) *mailersend.Message {
+	message := &mailersend.Message{
+		From:    mailersend.From{Name: "Sender", Email: "sender@example.com"},
+		Subject: "Subject",
+		Text:    "Greetings from the team, you got this message through MailerSend.",
+	}
+	for _, email := range to {
+		message.Recipients = append(message.Recipients, mailersend.Recipient{Email: email})
+	}
+
+	return message
+}
+
+func TestSendRecordsEmail(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := srv.NewMailersend()
+	ctx := context.TODO()
+
+	domain := srv.AddDomain("example.com")
+
+	result, err := ms.Email.SendWithResult(ctx, newMessage("john@client.com"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.MessageID)
+	assert.Equal(t, http.StatusAccepted, result.Response.StatusCode)
+
+	sent := srv.SentEmails()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, result.MessageID, sent[0].MessageID)
+	assert.Equal(t, "john@client.com", sent[0].Message.Recipients[0].Email)
+
+	message, _, err := ms.Message.Get(ctx, result.MessageID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ID, message.Data.Domain.ID)
+	assert.Equal(t, "Subject", message.Data.Emails[0].Subject)
+
+	recipients, _, err := ms.Domain.GetRecipients(ctx, &mailersend.GetRecipientsOptions{DomainID: domain.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "john@client.com", recipients.Data[0].Email)
+}
+
+func TestSendValidatesMessage(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := srv.NewMailersend()
+
+	message := newMessage("not-an-email")
+	message.Subject = ""
+
+	_, err := ms.Email.Send(context.TODO(), message)
+
+	assert.True(t, mailersend.IsValidationError(err))
+	assert.Contains(t, err.(*mailersend.ValidationError).Errors, "to.0.email")
+	assert.Contains(t, err.(*mailersend.ValidationError).Errors, "subject")
+	assert.Empty(t, srv.SentEmails())
+}
+
+func TestRequiresAPIKey(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := mailersend.NewMailersend("")
+	ms.SetClient(srv.Client())
+
+	_, _, err := ms.Domain.List(context.TODO(), nil)
+
+	assert.True(t, mailersend.IsAuthError(err))
+}
+
+func TestBulkEmailCompletes(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := srv.NewMailersend()
+	ctx := context.TODO()
+
+	res, _, err := ms.BulkEmail.Send(ctx, []*mailersend.Message{
+		newMessage("john@client.com"),
+		newMessage("jane@client.com"),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, srv.SentEmails())
+
+	var states []string
+	for i := 0; i < 3; i++ {
+		status, _, err := ms.BulkEmail.Status(ctx, res.BulkEmailID)
+		assert.NoError(t, err)
+		states = append(states, status.Data.State)
+
+		if status.Data.State == "completed" {
+			assert.Equal(t, 2, status.Data.TotalRecipientsCount)
+			assert.Len(t, status.Data.MessagesID, 2)
+		}
+	}
+
+	assert.Equal(t, []string{"processing", "completed", "completed"}, states)
+
+	sent := srv.SentEmails()
+	assert.Len(t, sent, 2)
+	assert.Equal(t, res.BulkEmailID, sent[1].BulkEmailID)
+}
+
+func TestCompleteBulkEmails(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := srv.NewMailersend()
+
+	_, _, err := ms.BulkEmail.Send(context.TODO(), []*mailersend.Message{newMessage("john@client.com")})
+	assert.NoError(t, err)
+
+	srv.CompleteBulkEmails()
+
+	assert.Len(t, srv.SentEmails(), 1)
+}
+
+func TestBulkEmailReportsFailures(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := srv.NewMailersend()
+	ctx := context.TODO()
+
+	srv.AddSuppression(mailersend.Unsubscribes, "", "jane@client.com")
+
+	invalid := newMessage("john@client.com")
+	invalid.From.Email = ""
+
+	res, _, err := ms.BulkEmail.Send(ctx, []*mailersend.Message{newMessage("jane@client.com", "john@client.com"), invalid})
+	assert.NoError(t, err)
+
+	status, _, err := ms.BulkEmail.WaitForCompletion(ctx, res.BulkEmailID, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, status.Data.ValidationErrorsCount)
+	assert.Equal(t, 1, status.Data.ValidationErrors[0].MessageIndex)
+	assert.Contains(t, status.Data.ValidationErrors[0].Errors, "from.email")
+	assert.Equal(t, mailersend.BulkEmailSuppressedRecipients{
+		{MessageIndex: 0, Field: "to", Email: "jane@client.com", Reasons: []string{"unsubscribed"}},
+	}, status.Data.SuppressedRecipients)
+	assert.Len(t, srv.SentEmails(), 1)
+}
+
+func TestDomains(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := srv.NewMailersend()
+	ctx := context.TODO()
+
+	for _, name := range []string{"a.com", "b.com", "c.com"} {
+		_, _, err := ms.Domain.Create(ctx, &mailersend.CreateDomainOptions{Name: name})
+		assert.NoError(t, err)
+	}
+
+	_, _, err := ms.Domain.Create(ctx, &mailersend.CreateDomainOptions{Name: "a.com"})
+	assert.True(t, mailersend.IsValidationError(err))
+
+	domains, err := ms.Domain.ListAll(ctx, &mailersend.ListDomainOptions{Limit: 2}).All()
+	assert.NoError(t, err)
+	assert.Len(t, domains, 3)
+	assert.False(t, domains[0].IsVerified)
+
+	verify, _, err := ms.Domain.Verify(ctx, domains[0].ID)
+	assert.NoError(t, err)
+	assert.True(t, verify.Data.Dkim)
+
+	paused := true
+	domain, _, err := ms.Domain.Update(ctx, &mailersend.DomainSettingOptions{DomainID: domains[0].ID, SendPaused: &paused})
+	assert.NoError(t, err)
+	assert.True(t, domain.Data.IsVerified)
+	assert.True(t, domain.Data.DomainSettings.SendPaused)
+
+	dns, _, err := ms.Domain.GetDNS(ctx, domains[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "mlsend2._domainkey.a.com", dns.Data.Dkim.Hostname)
+
+	_, err = ms.Domain.Delete(ctx, domains[0].ID)
+	assert.NoError(t, err)
+
+	_, _, err = ms.Domain.Get(ctx, domains[0].ID)
+	assert.True(t, mailersend.IsNotFound(err))
+}
+
+func TestSuppressedRecipientsAreNotSent(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := srv.NewMailersend()
+	ctx := context.TODO()
+
+	domain := srv.AddDomain("example.com")
+
+	_, _, err := ms.Suppression.CreateHardBounce(ctx, &mailersend.CreateSuppressionOptions{
+		DomainID:   domain.ID,
+		Recipients: []string{"bounced@client.com"},
+	})
+	assert.NoError(t, err)
+	srv.AddSuppression(mailersend.BlockList, domain.ID, "*@blocked.com", ".*@spam.com")
+
+	result, err := ms.Email.SendWithResult(ctx, newMessage("john@client.com", "bounced@client.com", "spam@blocked.com", "offer@spam.com"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.MessageID)
+	assert.Equal(t, mailersend.SendWarningSomeSuppressed, result.Warnings[0].Type)
+	assert.Len(t, result.Warnings[0].Recipients, 3)
+	assert.Len(t, srv.SentEmails()[0].Message.Recipients, 1)
+
+	result, err = ms.Email.SendWithResult(ctx, newMessage("bounced@client.com"))
+	assert.NoError(t, err)
+	assert.Empty(t, result.MessageID)
+	assert.Equal(t, mailersend.SendWarningAllSuppressed, result.Warnings[0].Type)
+
+	bounces, _, err := ms.Suppression.ListHardBounces(ctx, &mailersend.SuppressionOptions{DomainID: domain.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "bounced@client.com", bounces.Data[0].Recipient.Email)
+
+	_, err = ms.Suppression.DeleteAll(ctx, domain.ID, mailersend.HardBounces)
+	assert.NoError(t, err)
+
+	bounces, _, err = ms.Suppression.ListHardBounces(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, bounces.Data)
+}
+
+func TestWebhooksAndTemplates(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ms := srv.NewMailersend()
+	ctx := context.TODO()
+
+	domain := srv.AddDomain("example.com")
+
+	webhook, _, err := ms.Webhook.Create(ctx, &mailersend.CreateWebhookOptions{
+		Name:     "Activity",
+		DomainID: domain.ID,
+		URL:      "https://example.com/webhook",
+		Events:   []string{"activity.delivered"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, webhook.Data.Enabled)
+
+	disabled := false
+	_, _, err = ms.Webhook.Update(ctx, &mailersend.UpdateWebhookOptions{WebhookID: webhook.Data.ID, Enabled: &disabled})
+	assert.NoError(t, err)
+
+	webhooks, _, err := ms.Webhook.List(ctx, &mailersend.ListWebhookOptions{DomainID: domain.ID})
+	assert.NoError(t, err)
+	assert.Len(t, webhooks.Data, 1)
+	assert.False(t, webhooks.Data[0].Enabled)
+
+	_, err = ms.Webhook.Delete(ctx, webhook.Data.ID)
+	assert.NoError(t, err)
+
+	template := srv.AddTemplate("Welcome", domain.ID)
+
+	message := newMessage("john@client.com")
+	message.TemplateID = template.ID
+	_, err = ms.Email.Send(ctx, message)
+	assert.NoError(t, err)
+
+	single, _, err := ms.Template.Get(ctx, template.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Welcome", single.Data.Name)
+	assert.Equal(t, 1, single.Data.TemplateStats.Sent)
+
+	_, err = ms.Template.Delete(ctx, template.ID)
+	assert.NoError(t, err)
+
+	templates, _, err := ms.Template.List(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, templates.Data)
+
+	created, _, err := ms.Template.Create(ctx, &mailersend.CreateTemplateOptions{
+		Name:     "Receipt",
+		DomainID: domain.ID,
+		HTML:     "<p>Thanks</p>",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ID, created.Data.Domain.ID)
+
+	updated, _, err := ms.Template.Update(ctx, &mailersend.UpdateTemplateOptions{TemplateID: created.Data.ID, Name: "Invoice"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Invoice", updated.Data.Name)
+
+	_, _, err = ms.Template.Create(ctx, &mailersend.CreateTemplateOptions{Name: "Empty"})
+	assert.True(t, mailersend.IsValidationError(err))
+}
@@ -0,0 +1,247 @@
+package mailersendtest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+)
+
+// suppression is an entry on one of the suppression lists; blocklist entries may be patterns.
+type suppression struct {
+	id        string
+	value     string
+	pattern   bool
+	reason    string
+	domainID  string
+	createdAt time.Time
+}
+
+var suppressionTypes = []mailersend.SuppressionType{
+	mailersend.BlockList,
+	mailersend.HardBounces,
+	mailersend.SpamComplaints,
+	mailersend.Unsubscribes,
+}
+
+// suppressionReasons are the reasons reported in send warnings for each suppression list.
+var suppressionReasons = map[mailersend.SuppressionType]string{
+	mailersend.BlockList:      "blocklisted",
+	mailersend.HardBounces:    "hard_bounced",
+	mailersend.SpamComplaints: "spam_complaint",
+	mailersend.Unsubscribes:   "unsubscribed",
+}
+
+// AddSuppression - adds recipients, or blocklist patterns, to a suppression list of the domain
+func (s *Server) AddSuppression(suppressionType mailersend.SuppressionType, domainID string, recipients ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, recipient := range recipients {
+		s.addSuppression(suppressionType, domainID, recipient, suppressionType == mailersend.BlockList && strings.Contains(recipient, "*"))
+	}
+}
+
+func (s *Server) addSuppression(suppressionType mailersend.SuppressionType, domainID string, value string, pattern bool) *suppression {
+	entry := &suppression{
+		id:        newID(),
+		value:     value,
+		pattern:   pattern,
+		domainID:  domainID,
+		createdAt: now(),
+	}
+	switch suppressionType {
+	case mailersend.HardBounces:
+		entry.reason = "550 5.1.1 The email account that you tried to reach does not exist."
+	case mailersend.Unsubscribes:
+		entry.reason = "NO_LONGER_WANT"
+	}
+
+	s.suppressions[suppressionType] = append(s.suppressions[suppressionType], entry)
+
+	return entry
+}
+
+func (s *Server) handleSuppressions(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 1 || suppressionReasons[mailersend.SuppressionType(segments[0])] == "" {
+		notFound(w)
+		return
+	}
+	suppressionType := mailersend.SuppressionType(segments[0])
+
+	switch r.Method {
+	case http.MethodGet:
+		domainID := r.URL.Query().Get("domain_id")
+
+		var entries []*suppression
+		for _, entry := range s.suppressions[suppressionType] {
+			if domainID == "" || entry.domainID == domainID {
+				entries = append(entries, entry)
+			}
+		}
+
+		page, links, meta := paginate(r, entries)
+		writeJSON(w, http.StatusOK, s.suppressionRoot(suppressionType, page, links, meta))
+	case http.MethodPost:
+		var options mailersend.CreateSuppressionBlockOptions
+		if !decode(w, r, &options) {
+			return
+		}
+
+		errors := map[string][]string{}
+		if _, domain := s.findDomain(options.DomainID); domain == nil {
+			errors["domain_id"] = []string{"The selected domain id is invalid."}
+		}
+		if len(options.Recipients) == 0 && len(options.Patterns) == 0 {
+			errors["recipients"] = []string{"The recipients field is required."}
+		}
+		for _, recipient := range options.Recipients {
+			if !validEmail(recipient) {
+				errors["recipients"] = []string{"The recipients must be valid email addresses."}
+			}
+		}
+		if len(errors) > 0 {
+			validationFailed(w, errors)
+			return
+		}
+
+		var entries []*suppression
+		for _, recipient := range options.Recipients {
+			entries = append(entries, s.addSuppression(suppressionType, options.DomainID, recipient, false))
+		}
+		if suppressionType == mailersend.BlockList {
+			for _, pattern := range options.Patterns {
+				entries = append(entries, s.addSuppression(suppressionType, options.DomainID, pattern, true))
+			}
+		}
+
+		if suppressionType != mailersend.BlockList {
+			writeJSON(w, http.StatusCreated, s.suppressionRoot(suppressionType, entries, mailersend.Links{}, mailersend.Meta{}))
+			return
+		}
+
+		data := []mailersend.SuppressionBlockData{}
+		for _, entry := range entries {
+			data = append(data, mailersend.SuppressionBlockData{
+				ID:        entry.id,
+				Type:      blockType(entry),
+				Pattern:   entry.value,
+				CreatedAt: entry.createdAt,
+				UpdatedAt: entry.createdAt,
+			})
+		}
+		writeJSON(w, http.StatusCreated, mailersend.SuppressionBlockResponse{Data: data})
+	case http.MethodDelete:
+		var options struct {
+			DomainID string   `json:"domain_id"`
+			Ids      []string `json:"ids"`
+			All      bool     `json:"all"`
+		}
+		if !decode(w, r, &options) {
+			return
+		}
+		if !options.All && len(options.Ids) == 0 {
+			validationFailed(w, map[string][]string{"ids": {"The ids field is required when all is not present."}})
+			return
+		}
+
+		ids := map[string]bool{}
+		for _, id := range options.Ids {
+			ids[id] = true
+		}
+
+		kept := s.suppressions[suppressionType][:0]
+		for _, entry := range s.suppressions[suppressionType] {
+			inDomain := options.DomainID == "" || entry.domainID == options.DomainID
+			if inDomain && (options.All || ids[entry.id]) {
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		s.suppressions[suppressionType] = kept
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// suppressionRoot renders entries in the response format of the suppression list.
+func (s *Server) suppressionRoot(suppressionType mailersend.SuppressionType, entries []*suppression, links mailersend.Links, meta mailersend.Meta) interface{} {
+	switch suppressionType {
+	case mailersend.BlockList:
+		data := []mailersend.SuppressionBlockListData{}
+		for _, entry := range entries {
+			_, domain := s.findDomain(entry.domainID)
+			data = append(data, mailersend.SuppressionBlockListData{
+				ID:        entry.id,
+				Type:      blockType(entry),
+				Pattern:   entry.value,
+				Domain:    valueOf(domain),
+				CreatedAt: entry.createdAt,
+				UpdatedAt: entry.createdAt,
+			})
+		}
+		return mailersend.SuppressionBlockListRoot{Data: data, Links: links, Meta: meta}
+	case mailersend.HardBounces:
+		data := []mailersend.SuppressionHardBouncesData{}
+		for _, entry := range entries {
+			data = append(data, mailersend.SuppressionHardBouncesData{
+				ID:        entry.id,
+				Reason:    entry.reason,
+				CreatedAt: entry.createdAt,
+				Recipient: s.suppressionRecipient(entry),
+			})
+		}
+		return mailersend.SuppressionHardBouncesRoot{Data: data, Links: links, Meta: meta}
+	case mailersend.SpamComplaints:
+		data := []mailersend.SuppressionSpamComplaintsData{}
+		for _, entry := range entries {
+			data = append(data, mailersend.SuppressionSpamComplaintsData{
+				ID:        entry.id,
+				Recipient: s.suppressionRecipient(entry),
+				CreatedAt: entry.createdAt,
+			})
+		}
+		return mailersend.SuppressionSpamComplaintsRoot{Data: data, Links: links, Meta: meta}
+	default:
+		data := []mailersend.SuppressionUnsubscribesData{}
+		for _, entry := range entries {
+			data = append(data, mailersend.SuppressionUnsubscribesData{
+				ID:             entry.id,
+				Reason:         entry.reason,
+				ReadableReason: "I no longer want to receive these emails",
+				Recipient:      s.suppressionRecipient(entry),
+				CreatedAt:      entry.createdAt,
+			})
+		}
+		return mailersend.SuppressionUnsubscribesRoot{Data: data, Links: links, Meta: meta}
+	}
+}
+
+func (s *Server) suppressionRecipient(entry *suppression) mailersend.SuppressionRecipient {
+	_, domain := s.findDomain(entry.domainID)
+
+	return mailersend.SuppressionRecipient{
+		ID:        s.recipientID(strings.ToLower(entry.value)),
+		Email:     entry.value,
+		CreatedAt: entry.createdAt,
+		UpdatedAt: entry.createdAt,
+		Domain:    valueOf(domain),
+	}
+}
+
+func blockType(entry *suppression) string {
+	if entry.pattern {
+		return "pattern"
+	}
+	return "exact"
+}
+
+func valueOf(domain *mailersend.Domain) mailersend.Domain {
+	if domain == nil {
+		return mailersend.Domain{}
+	}
+	return *domain
+}
@@ -0,0 +1,153 @@
+package mailersendtest
+
+import (
+	"net/http"
+
+	"github.com/mailersend/mailersend-go"
+)
+
+// AddTemplate - adds a template to the domain and returns it
+func (s *Server) AddTemplate(name string, domainID string) mailersend.SingleTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	template := &mailersend.SingleTemplate{
+		ID:        newID(),
+		Name:      name,
+		Type:      "html",
+		CreatedAt: now(),
+	}
+	if _, domain := s.findDomain(domainID); domain != nil {
+		template.Domain = *domain
+	}
+	s.templates = append(s.templates, template)
+
+	return *template
+}
+
+func (s *Server) handleTemplates(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		domainID := r.URL.Query().Get("domain_id")
+
+		templates := make([]mailersend.Template, 0, len(s.templates))
+		for _, template := range s.templates {
+			if domainID != "" && template.Domain.ID != domainID {
+				continue
+			}
+			templates = append(templates, mailersend.Template{
+				ID:        template.ID,
+				Name:      template.Name,
+				Type:      template.Type,
+				ImagePath: template.ImagePath,
+				CreatedAt: template.CreatedAt.Format(timeFormat),
+			})
+		}
+
+		page, links, meta := paginate(r, templates)
+		writeJSON(w, http.StatusOK, mailersend.TemplateRoot{Data: page, Links: links, Meta: meta})
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var options mailersend.CreateTemplateOptions
+		if !decode(w, r, &options) {
+			return
+		}
+
+		errors := map[string][]string{}
+		if options.Name == "" {
+			errors["name"] = []string{"The name field is required."}
+		}
+		if options.HTML == "" {
+			errors["html"] = []string{"The html field is required."}
+		}
+		_, domain := s.findDomain(options.DomainID)
+		if options.DomainID != "" && domain == nil {
+			errors["domain_id"] = []string{"The selected domain id is invalid."}
+		}
+		if len(errors) > 0 {
+			validationFailed(w, errors)
+			return
+		}
+
+		template := &mailersend.SingleTemplate{
+			ID:        newID(),
+			Name:      options.Name,
+			Type:      "html",
+			CreatedAt: now(),
+			Category:  templateCategory(options.CategoryID),
+			Domain:    valueOf(domain),
+		}
+		s.templates = append(s.templates, template)
+
+		writeJSON(w, http.StatusCreated, mailersend.SingleTemplateRoot{Data: *template})
+	case len(segments) == 1 && (r.Method == http.MethodGet || r.Method == http.MethodPut || r.Method == http.MethodDelete):
+		for i, template := range s.templates {
+			if template.ID != segments[0] {
+				continue
+			}
+
+			if r.Method == http.MethodDelete {
+				s.templates = append(s.templates[:i], s.templates[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if r.Method == http.MethodPut {
+				var options mailersend.UpdateTemplateOptions
+				if !decode(w, r, &options) {
+					return
+				}
+
+				if options.DomainID != "" {
+					_, domain := s.findDomain(options.DomainID)
+					if domain == nil {
+						validationFailed(w, map[string][]string{"domain_id": {"The selected domain id is invalid."}})
+						return
+					}
+					template.Domain = *domain
+				}
+				if options.Name != "" {
+					template.Name = options.Name
+				}
+				if options.CategoryID != "" {
+					template.Category = templateCategory(options.CategoryID)
+				}
+			}
+
+			data := *template
+			data.TemplateStats = s.templateStats(template.ID)
+			writeJSON(w, http.StatusOK, mailersend.SingleTemplateRoot{Data: data})
+			return
+		}
+		notFound(w)
+	case len(segments) <= 1:
+		methodNotAllowed(w)
+	default:
+		notFound(w)
+	}
+}
+
+// templateCategory renders the category of a template, nil when it has none.
+func templateCategory(categoryID string) interface{} {
+	if categoryID == "" {
+		return nil
+	}
+
+	return map[string]string{"id": categoryID}
+}
+
+// templateStats counts the emails sent with the template; every accepted email counts as delivered.
+func (s *Server) templateStats(templateID string) mailersend.TemplateStats {
+	var stats mailersend.TemplateStats
+	for _, sent := range s.sent {
+		if sent.Message.TemplateID != templateID {
+			continue
+		}
+
+		stats.Total += len(sent.Message.Recipients)
+		stats.Sent += len(sent.Message.Recipients)
+		stats.Delivered += len(sent.Message.Recipients)
+		stats.LastEmailSentAt = sent.SentAt
+	}
+
+	return stats
+}
@@ -0,0 +1,118 @@
+package mailersendtest
+
+import (
+	"net/http"
+
+	"github.com/mailersend/mailersend-go"
+)
+
+func (s *Server) handleWebhooks(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			domainID := r.URL.Query().Get("domain_id")
+			if domainID == "" {
+				validationFailed(w, map[string][]string{"domain_id": {"The domain id field is required."}})
+				return
+			}
+
+			webhooks := []mailersend.Webhook{}
+			for _, webhook := range s.webhooks {
+				if webhook.Domain.ID == domainID {
+					webhooks = append(webhooks, *webhook)
+				}
+			}
+
+			page, links, meta := paginate(r, webhooks)
+			writeJSON(w, http.StatusOK, mailersend.WebhookRoot{Data: page, Links: links, Meta: meta})
+		case http.MethodPost:
+			var options mailersend.CreateWebhookOptions
+			if !decode(w, r, &options) {
+				return
+			}
+
+			errors := map[string][]string{}
+			if options.Name == "" {
+				errors["name"] = []string{"The name field is required."}
+			}
+			if options.URL == "" {
+				errors["url"] = []string{"The url field is required."}
+			}
+			if len(options.Events) == 0 {
+				errors["events"] = []string{"The events field is required."}
+			}
+			_, domain := s.findDomain(options.DomainID)
+			if domain == nil {
+				errors["domain_id"] = []string{"The selected domain id is invalid."}
+			}
+			if len(errors) > 0 {
+				validationFailed(w, errors)
+				return
+			}
+
+			createdAt := now()
+			webhook := &mailersend.Webhook{
+				ID:        newID(),
+				URL:       options.URL,
+				Events:    options.Events,
+				Name:      options.Name,
+				Enabled:   options.Enabled == nil || *options.Enabled,
+				Editable:  true,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+				Domain:    *domain,
+			}
+			s.webhooks = append(s.webhooks, webhook)
+
+			writeJSON(w, http.StatusCreated, mailersend.SingleWebhookRoot{Data: *webhook})
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	if len(segments) > 1 {
+		notFound(w)
+		return
+	}
+
+	for i, webhook := range s.webhooks {
+		if webhook.ID != segments[0] {
+			continue
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, mailersend.SingleWebhookRoot{Data: *webhook})
+		case http.MethodPut:
+			var options mailersend.UpdateWebhookOptions
+			if !decode(w, r, &options) {
+				return
+			}
+
+			if options.Name != "" {
+				webhook.Name = options.Name
+			}
+			if options.URL != "" {
+				webhook.URL = options.URL
+			}
+			if options.Enabled != nil {
+				webhook.Enabled = *options.Enabled
+			}
+			if len(options.Events) > 0 {
+				webhook.Events = options.Events
+			}
+			webhook.UpdatedAt = now()
+
+			writeJSON(w, http.StatusOK, mailersend.SingleWebhookRoot{Data: *webhook})
+		case http.MethodDelete:
+			s.webhooks = append(s.webhooks[:i], s.webhooks[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	notFound(w)
+}
@@ -11,6 +11,7 @@ const messageBasePath = "/messages"
 
 type MessageService interface {
 	List(ctx context.Context, options *ListMessageOptions) (*MessageRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListMessageOptions) *Iterator[MessageData]
 	Get(ctx context.Context, messageID string) (*SingleMessageRoot, *Response, error)
 }
 
@@ -68,7 +69,7 @@ func (s *messageService) List(ctx context.Context, options *ListMessageOptions)
 	}
 
 	root := new(MessageRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Message.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -76,6 +77,24 @@ func (s *messageService) List(ctx context.Context, options *ListMessageOptions)
 	return root, res, nil
 }
 
+func (s *messageService) ListAll(ctx context.Context, options *ListMessageOptions) *Iterator[MessageData] {
+	opts := ListMessageOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]MessageData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *messageService) Get(ctx context.Context, messageID string) (*SingleMessageRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", messageBasePath, messageID)
 
@@ -85,7 +104,7 @@ func (s *messageService) Get(ctx context.Context, messageID string) (*SingleMess
 	}
 
 	root := new(SingleMessageRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Message.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
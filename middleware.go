@@ -0,0 +1,210 @@
+package mailersend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Doer - sends a request and returns its response, like *http.Client
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc - adapts a function to the Doer interface
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do - calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware - wraps the Doer that sends every API request.
+// The request reaches the middleware after it is built and before it is sent,
+// and the middleware sees the response before it is checked and decoded.
+type Middleware func(next Doer) Doer
+
+// Use - Add middleware to the client, the first middleware added is the outermost.
+// Middleware wraps the whole call, including rate limiting and retries.
+func (ms *Mailersend) Use(middleware ...Middleware) {
+	ms.update(func(state *clientState) {
+		state.middleware = append(state.middleware[:len(state.middleware):len(state.middleware)], middleware...)
+	})
+}
+
+// doer returns the middleware chain wrapped around the retrying transport.
+func (state *clientState) doer() Doer {
+	var doer Doer = DoerFunc(state.sendWithRetry)
+	for i := len(state.middleware) - 1; i >= 0; i-- {
+		doer = state.middleware[i](doer)
+	}
+
+	return doer
+}
+
+type callInfoKey struct{}
+
+// callInfo describes the API call a request belongs to, for middleware.
+type callInfo struct {
+	operation string
+	attempts  int32
+}
+
+func withCallInfo(ctx context.Context, info *callInfo) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+func callInfoFromContext(ctx context.Context) *callInfo {
+	info, _ := ctx.Value(callInfoKey{}).(*callInfo)
+	return info
+}
+
+// OperationFromContext - returns the API operation of a request, named after the service and method like "Domain.Verify".
+// Use it in middleware with req.Context(), it is empty outside of a call made by the client.
+func OperationFromContext(ctx context.Context) string {
+	if info := callInfoFromContext(ctx); info != nil {
+		return info.operation
+	}
+	return ""
+}
+
+// AttemptsFromContext - returns how many times the request was sent, including retries.
+// Use it in middleware with req.Context() once the next Doer returned.
+func AttemptsFromContext(ctx context.Context) int {
+	if info := callInfoFromContext(ctx); info != nil {
+		return int(atomic.LoadInt32(&info.attempts))
+	}
+	return 0
+}
+
+// Logger - used by LoggingMiddleware, implemented by *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+const (
+	redacted            = "[REDACTED]"
+	maxLoggedBodyLength = 4096
+)
+
+// redactedBodyFields are the JSON fields holding email content or credentials, which are never logged.
+var redactedBodyFields = map[string]bool{
+	"html":           true,
+	"text":           true,
+	"content":        true,
+	"accessToken":    true,
+	"secret":         true,
+	"signing_secret": true,
+	"password":       true,
+}
+
+// LoggingMiddleware - logs every request and response.
+// The api key, the html, text and attachment content of emails, and the access tokens,
+// secrets and passwords in request and response bodies are redacted.
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			headers := req.Header.Clone()
+			if headers.Get("Authorization") != "" {
+				headers.Set("Authorization", "Bearer "+redacted)
+			}
+			logger.Printf("mailersend: --> %s %s headers=%v body=%s", req.Method, req.URL, headers, requestBodyForLog(req))
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			elapsed := time.Since(start)
+
+			if err != nil {
+				logger.Printf("mailersend: <-- %s %s error=%v (%s)", req.Method, req.URL, err, elapsed)
+				return resp, err
+			}
+
+			logger.Printf("mailersend: <-- %s %s %d (%s) body=%s", req.Method, req.URL, resp.StatusCode, elapsed, responseBodyForLog(resp))
+
+			return resp, nil
+		})
+	}
+}
+
+func requestBodyForLog(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return ""
+		}
+		defer body.Close()
+
+		data, _ := io.ReadAll(body)
+		return redactBody(data)
+	}
+
+	data, _ := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+
+	return redactBody(data)
+}
+
+func responseBodyForLog(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	return redactBody(data)
+}
+
+// redactBody replaces email content in a JSON body and truncates it for logging.
+func redactBody(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return "[" + http.DetectContentType(data) + "]"
+	}
+
+	out, err := json.Marshal(redactValue(body))
+	if err != nil {
+		return ""
+	}
+
+	if len(out) > maxLoggedBodyLength {
+		return string(out[:maxLoggedBodyLength]) + "..."
+	}
+
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if redactedBodyFields[key] {
+				if field != nil && field != "" {
+					value[key] = redacted
+				}
+				continue
+			}
+			value[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,183 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	var calls []string
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls = append(calls, "transport "+req.Header.Get("X-Trace-Id"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": {"id": "domain-id"}}`)),
+		}
+	}))
+
+	record := func(name string) mailersend.Middleware {
+		return func(next mailersend.Doer) mailersend.Doer {
+			return mailersend.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, "before "+name)
+				resp, err := next.Do(req)
+				calls = append(calls, "after "+name)
+				return resp, err
+			})
+		}
+	}
+
+	ms.Use(record("outer"), func(next mailersend.Doer) mailersend.Doer {
+		return mailersend.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Trace-Id", "trace")
+			return next.Do(req)
+		})
+	})
+	ms.Use(record("inner"))
+
+	root, _, err := ms.Domain.Get(context.TODO(), "domain-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "domain-id", root.Data.ID)
+	assert.Equal(t, []string{"before outer", "before inner", "transport trace", "after inner", "after outer"}, calls)
+}
+
+func TestLoggingMiddlewareRedacts(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	var sent []byte
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		sent, _ = io.ReadAll(req.Body)
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Header:     http.Header{"X-Message-Id": []string{"message-id"}},
+			Body:       http.NoBody,
+		}
+	}))
+
+	var logs bytes.Buffer
+	ms.Use(mailersend.LoggingMiddleware(log.New(&logs, "", 0)))
+
+	message := basicEmailNew()
+	message.AddAttachment(mailersend.Attachment{Filename: "secret.txt", Content: "c2VjcmV0IGF0dGFjaG1lbnQ="})
+
+	_, err := ms.Email.Send(context.TODO(), message)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(sent), "c2VjcmV0IGF0dGFjaG1lbnQ=")
+	assert.Contains(t, logs.String(), "POST https://api.mailersend.com/v1/email")
+	assert.Contains(t, logs.String(), "Bearer [REDACTED]")
+	assert.Contains(t, logs.String(), "202")
+	assert.Contains(t, logs.String(), subject)
+	assert.NotContains(t, logs.String(), testKey)
+	assert.NotContains(t, logs.String(), "HTML content")
+	assert.NotContains(t, logs.String(), text)
+	assert.NotContains(t, logs.String(), "c2VjcmV0IGF0dGFjaG1lbnQ=")
+}
+
+func TestLoggingMiddlewareRedactsCredentials(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	token := "mlsn.2b1ec1b9d8e1c2a5f3d4e6f7a8b9c0d1"
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": {"id": "token-id", "accessToken": "` + token + `", "name": "ci"}}`)),
+		}
+	}))
+
+	var logs bytes.Buffer
+	ms.Use(mailersend.LoggingMiddleware(log.New(&logs, "", 0)))
+
+	root, _, err := ms.Token.Create(context.TODO(), &mailersend.CreateTokenOptions{Name: "ci", DomainID: "domain-id", Scopes: []string{"email_full"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, token, root.Data.AccessToken)
+	assert.Contains(t, logs.String(), "token-id")
+	assert.NotContains(t, logs.String(), token)
+}
+
+func TestMiddlewareSeesOperationAndAttempts(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(testRetryPolicy())
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		if calls == 1 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`))}
+	}))
+
+	var operations []string
+	var attempts []int
+	ms.Use(func(next mailersend.Doer) mailersend.Doer {
+		return mailersend.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			operations = append(operations, mailersend.OperationFromContext(req.Context()))
+			attempts = append(attempts, mailersend.AttemptsFromContext(req.Context()))
+			return resp, err
+		})
+	})
+
+	_, _, err := ms.Domain.Verify(context.TODO(), "domain-id")
+	assert.NoError(t, err)
+
+	_, err = ms.Domain.ListAll(context.TODO(), nil).All()
+	assert.NoError(t, err)
+
+	// The deprecated Send is reported as the Email.Send it calls.
+	_, err = ms.Send(context.TODO(), basicEmail())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Domain.Verify", "Domain.List", "Email.Send"}, operations)
+	assert.Equal(t, []int{2, 1, 1}, attempts)
+}
+
+func TestOperationNamesMatchServiceMethods(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`))}
+	}))
+
+	var operations []string
+	ms.Use(func(next mailersend.Doer) mailersend.Doer {
+		return mailersend.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			operations = append(operations, mailersend.OperationFromContext(req.Context()))
+			return next.Do(req)
+		})
+	})
+
+	// Methods built on other methods report the requests of those.
+	builtOn := map[string]string{
+		"BulkEmail.WaitForCompletion": "BulkEmail.Status",
+		"Suppression.Export":          "Suppression.List",
+		"Suppression.Find":            "Suppression.List",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	for name, call := range serviceCalls(ms, ctx) {
+		operations = nil
+		call()
+
+		expected := name
+		if operation, ok := builtOn[name]; ok {
+			expected = operation
+		}
+		for _, operation := range operations {
+			assert.Equal(t, expected, operation, name)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package mailersend
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// UserAgent - the User-Agent header sent with every request
+const UserAgent = "Mailersend-Client-Golang-v1"
+
+type clientOptions struct {
+	baseURL         string
+	httpClient      *http.Client
+	timeout         time.Duration
+	userAgentSuffix string
+	retryPolicy     *RetryPolicy
+	rateLimiter     *RateLimiter
+	middleware      []Middleware
+	idempotency     *idempotencyOption
+	guard           *SuppressionGuard
+	defaultFrom     *From
+	defaultSettings *Settings
+}
+
+// Option - configures the client created by NewMailersend
+type Option func(*clientOptions)
+
+// WithBaseURL - Set the base url of the API, like "http://localhost:8080/v1", instead of APIBase
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient - Set the http client used to send requests instead of http.DefaultClient
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithTimeout - Set the timeout of every request, the http client is copied rather than modified
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgentSuffix - Add a suffix, like "billing/1.2.0", to the User-Agent header to tag traffic per app
+func WithUserAgentSuffix(suffix string) Option {
+	return func(o *clientOptions) {
+		o.userAgentSuffix = strings.TrimSpace(suffix)
+	}
+}
+
+// WithRetry - Set the policy used to retry failed requests, see SetRetryPolicy
+func WithRetry(policy *RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithRateLimiter - Set the limiter used to throttle outgoing requests, see SetRateLimiter
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.rateLimiter = limiter
+	}
+}
+
+// WithMiddleware - Add middleware to the client, see Use
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *clientOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+type idempotencyOption struct {
+	store IdempotencyStore
+	ttl   time.Duration
+}
+
+// WithIdempotencyStore - Set the store that short-circuits duplicate sends, see SetIdempotencyStore
+func WithIdempotencyStore(store IdempotencyStore, ttl time.Duration) Option {
+	return func(o *clientOptions) {
+		o.idempotency = &idempotencyOption{store: store, ttl: ttl}
+	}
+}
+
+// WithSuppressionGuard - Check the recipients of sends against the suppression lists, see SetSuppressionGuard
+func WithSuppressionGuard(guard *SuppressionGuard) Option {
+	return func(o *clientOptions) {
+		o.guard = guard
+	}
+}
+
+// WithDefaultFrom - Set the sender of every message created by EmailService.NewMessage
+func WithDefaultFrom(from From) Option {
+	return func(o *clientOptions) {
+		o.defaultFrom = &from
+	}
+}
+
+// WithDefaultSettings - Set the tracking settings of every message created by EmailService.NewMessage
+func WithDefaultSettings(settings Settings) Option {
+	return func(o *clientOptions) {
+		o.defaultSettings = &settings
+	}
+}
+
+func (o *clientOptions) apply(state *clientState) {
+	if o.baseURL != "" {
+		state.apiBase = o.baseURL
+	}
+	if o.httpClient != nil {
+		state.client = o.httpClient
+	}
+	if o.timeout > 0 {
+		client := *state.client
+		client.Timeout = o.timeout
+		state.client = &client
+	}
+	if o.userAgentSuffix != "" {
+		state.userAgent = UserAgent + " " + o.userAgentSuffix
+	}
+	if o.retryPolicy != nil {
+		state.retryPolicy = o.retryPolicy
+	}
+	if o.rateLimiter != nil {
+		state.rateLimiter = o.rateLimiter
+	}
+	state.middleware = append(state.middleware, o.middleware...)
+	if o.guard != nil {
+		state.suppressionGuard = o.guard
+	}
+	if o.idempotency != nil {
+		state.idempotencyStore = o.idempotency.store
+		state.idempotencyTTL = o.idempotency.ttl
+	}
+	state.defaultFrom = o.defaultFrom
+	state.defaultSettings = o.defaultSettings
+}
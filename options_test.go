@@ -0,0 +1,51 @@
+package mailersend_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMailersendWithOptions(t *testing.T) {
+	var paths, agents []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		agents = append(agents, r.Header.Get("User-Agent"))
+		if len(paths) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "domain-id"}}`))
+	}))
+	defer srv.Close()
+
+	ms := mailersend.NewMailersend(testKey,
+		mailersend.WithBaseURL(srv.URL+"/v1/"),
+		mailersend.WithHTTPClient(srv.Client()),
+		mailersend.WithTimeout(5*time.Second),
+		mailersend.WithUserAgentSuffix("billing/1.2.0"),
+		mailersend.WithRetry(testRetryPolicy()),
+	)
+
+	root, _, err := ms.Domain.Get(context.TODO(), "domain-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "domain-id", root.Data.ID)
+	assert.Equal(t, []string{"/v1/domains/domain-id", "/v1/domains/domain-id"}, paths)
+	assert.Equal(t, mailersend.UserAgent+" billing/1.2.0", agents[0])
+	assert.Equal(t, 5*time.Second, ms.Client().Timeout)
+	assert.NotSame(t, srv.Client(), ms.Client())
+}
+
+func TestWithTimeoutDoesNotModifyDefaultClient(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey, mailersend.WithTimeout(time.Second))
+
+	assert.Equal(t, time.Second, ms.Client().Timeout)
+	assert.Zero(t, http.DefaultClient.Timeout)
+	assert.Same(t, http.DefaultClient, mailersend.NewMailersend(testKey).Client())
+}
@@ -0,0 +1,161 @@
+// Package otelmailersend instruments the MailerSend client with OpenTelemetry.
+//
+//	ms := mailersend.NewMailersend(apiKey)
+//	ms.Use(otelmailersend.Middleware())
+//
+// Every API call gets a client span named after the service and method, like
+// "mailersend.Domain.Verify", and is counted in the request duration, request
+// and error metrics.
+package otelmailersend
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/mailersend/mailersend-go/otelmailersend"
+
+// Attribute keys specific to MailerSend.
+const (
+	OperationKey          = attribute.Key("mailersend.operation")
+	RetryCountKey         = attribute.Key("mailersend.retry_count")
+	RateLimitLimitKey     = attribute.Key("mailersend.rate_limit.limit")
+	RateLimitRemainingKey = attribute.Key("mailersend.rate_limit.remaining")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option - configures the middleware
+type Option func(*config)
+
+// WithTracerProvider - Set the tracer provider, the global one is used by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider - Set the meter provider, the global one is used by default
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Middleware - returns middleware that traces and measures every API call.
+// Add it first so the span covers other middleware, rate limiting and retries.
+func Middleware(opts ...Option) mailersend.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram("mailersend.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of MailerSend API calls, including retries."))
+	if err != nil {
+		otel.Handle(err)
+	}
+	requests, err := meter.Int64Counter("mailersend.client.requests",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of MailerSend API calls."))
+	if err != nil {
+		otel.Handle(err)
+	}
+	failures, err := meter.Int64Counter("mailersend.client.errors",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of MailerSend API calls that failed or returned an error status."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next mailersend.Doer) mailersend.Doer {
+		return mailersend.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			operation := mailersend.OperationFromContext(req.Context())
+			name := "mailersend"
+			if operation != "" {
+				name += "." + operation
+			}
+
+			attrs := []attribute.KeyValue{
+				OperationKey.String(operation),
+				semconv.HTTPRequestMethodKey.String(req.Method),
+			}
+
+			ctx, span := tracer.Start(req.Context(), name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(
+					semconv.URLFull(req.URL.String()),
+					semconv.ServerAddress(req.URL.Hostname()),
+				))
+			defer span.End()
+
+			start := time.Now()
+			resp, err := next.Do(req.WithContext(ctx))
+			elapsed := time.Since(start)
+
+			if attempts := mailersend.AttemptsFromContext(ctx); attempts > 0 {
+				span.SetAttributes(RetryCountKey.Int(attempts - 1))
+			}
+
+			failed := false
+			switch {
+			case err != nil:
+				failed = true
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				attrs = append(attrs, semconv.ErrorType(err))
+			default:
+				attrs = append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+				span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+				span.SetAttributes(rateLimitAttributes(resp.Header)...)
+
+				if resp.StatusCode >= http.StatusBadRequest {
+					failed = true
+					span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+					attrs = append(attrs, semconv.ErrorTypeKey.String(strconv.Itoa(resp.StatusCode)))
+				}
+			}
+
+			set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+			duration.Record(ctx, elapsed.Seconds(), set)
+			requests.Add(ctx, 1, set)
+			if failed {
+				failures.Add(ctx, 1, set)
+			}
+
+			return resp, err
+		})
+	}
+}
+
+func rateLimitAttributes(header http.Header) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		attrs = append(attrs, RateLimitLimitKey.Int(limit))
+	}
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		attrs = append(attrs, RateLimitRemainingKey.Int(remaining))
+	}
+
+	return attrs
+}
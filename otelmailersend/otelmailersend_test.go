@@ -0,0 +1,127 @@
+package otelmailersend_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/mailersend/mailersend-go/otelmailersend"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type roundTripFunc func(req *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func instrumentedClient(t *testing.T, fn roundTripFunc) (*mailersend.Mailersend, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	ms := mailersend.NewMailersend("api-key")
+	ms.SetClient(&http.Client{Transport: fn})
+	ms.Use(otelmailersend.Middleware(
+		otelmailersend.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		otelmailersend.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	))
+
+	return ms, spans, reader
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestSpanPerCall(t *testing.T) {
+	var calls int
+	ms, spans, reader := instrumentedClient(t, func(req *http.Request) *http.Response {
+		calls++
+		if calls == 1 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Header: http.Header{}}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"X-Ratelimit-Limit":     []string{"60"},
+				"X-Ratelimit-Remaining": []string{"59"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(`{"message": "verified", "data": {"dkim": true}}`)),
+		}
+	})
+	ms.SetRetryPolicy(&mailersend.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	_, _, err := ms.Domain.Verify(context.TODO(), "domain-id")
+	assert.NoError(t, err)
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 1)
+	assert.Equal(t, "mailersend.Domain.Verify", ended[0].Name())
+
+	attrs := attributes(ended[0])
+	assert.Equal(t, "Domain.Verify", attrs["mailersend.operation"].AsString())
+	assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, int64(1), attrs["mailersend.retry_count"].AsInt64())
+	assert.Equal(t, int64(60), attrs["mailersend.rate_limit.limit"].AsInt64())
+	assert.Equal(t, int64(59), attrs["mailersend.rate_limit.remaining"].AsInt64())
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.TODO(), &rm))
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	assert.Equal(t, int64(1), metrics["mailersend.client.requests"].Data.(metricdata.Sum[int64]).DataPoints[0].Value)
+	assert.Equal(t, uint64(1), metrics["mailersend.client.request.duration"].Data.(metricdata.Histogram[float64]).DataPoints[0].Count)
+	assert.NotContains(t, metrics, "mailersend.client.errors")
+}
+
+func TestErrorsAreRecorded(t *testing.T) {
+	ms, spans, reader := instrumentedClient(t, func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"message": "Resource not found."}`)),
+			Request:    req,
+		}
+	})
+
+	_, _, err := ms.Template.Get(context.TODO(), "missing")
+	assert.True(t, mailersend.IsNotFound(err))
+
+	span := spans.Ended()[0]
+	assert.Equal(t, "mailersend.Template.Get", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.TODO(), &rm))
+
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "mailersend.client.errors" {
+			continue
+		}
+
+		point := m.Data.(metricdata.Sum[int64]).DataPoints[0]
+		assert.Equal(t, int64(1), point.Value)
+
+		errorType, _ := point.Attributes.Value("error.type")
+		assert.Equal(t, "404", errorType.AsString())
+		return
+	}
+	t.Fatal("errors metric not recorded")
+}
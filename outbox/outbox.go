@@ -0,0 +1,350 @@
+// Package outbox sends emails and sms through MailerSend durably.
+//
+// Messages are persisted in a Store before they are sent, and a worker drains the store
+// through EmailService.Send and SmsService.Send, retrying failures with backoff and moving
+// messages that cannot be sent to a dead letter status. A message added to the outbox is
+// sent even if the process crashes before sending it.
+//
+//	box := outbox.New(ms, store)
+//	id, err := box.AddEmail(ctx, message)
+//
+//	go box.Run(ctx)
+//
+// Delivery is at least once. An entry is sent again when the process crashes between sending it
+// and recording it as sent, and when a send times out or fails with a server error after the API
+// may already have accepted it. Every entry is sent with an Idempotency-Key, but the API is not
+// known to deduplicate on it. To send such entries at most once, give the client a durable
+// mailersend.IdempotencyStore with SetIdempotencyStore: the key is then reserved before sending,
+// and an entry whose earlier attempt had an unknown outcome is dead-lettered with
+// mailersend.ErrIdempotencyKeyInUse instead of being sent again.
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+)
+
+// Defaults used when the matching option is not given.
+const (
+	DefaultMaxAttempts  = 10
+	DefaultMinBackoff   = 30 * time.Second
+	DefaultMaxBackoff   = time.Hour
+	DefaultPollInterval = 5 * time.Second
+	DefaultBatchSize    = 50
+)
+
+// Outbox - persists messages and sends them with a worker
+type Outbox struct {
+	client       *mailersend.Mailersend
+	store        Store
+	maxAttempts  int
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	pollInterval time.Duration
+	batchSize    int
+	logger       mailersend.Logger
+	onDeadLetter func(entry *Entry)
+	now          func() time.Time
+}
+
+// Option - configures an Outbox
+type Option func(*Outbox)
+
+// WithMaxAttempts - Set how many times an entry is sent before it is dead-lettered
+func WithMaxAttempts(attempts int) Option {
+	return func(o *Outbox) {
+		o.maxAttempts = attempts
+	}
+}
+
+// WithBackoff - Set the delay before the first retry, doubled on every further retry up to max
+func WithBackoff(min time.Duration, max time.Duration) Option {
+	return func(o *Outbox) {
+		o.minBackoff = min
+		o.maxBackoff = max
+	}
+}
+
+// WithPollInterval - Set how often Run looks for due entries when the outbox is idle
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *Outbox) {
+		o.pollInterval = interval
+	}
+}
+
+// WithBatchSize - Set how many entries are sent per Drain
+func WithBatchSize(size int) Option {
+	return func(o *Outbox) {
+		o.batchSize = size
+	}
+}
+
+// WithLogger - Set the logger for store errors hit by Run and for dead-lettered entries
+func WithLogger(logger mailersend.Logger) Option {
+	return func(o *Outbox) {
+		o.logger = logger
+	}
+}
+
+// WithDeadLetterHandler - Set a function called with every entry that is dead-lettered
+func WithDeadLetterHandler(handler func(entry *Entry)) Option {
+	return func(o *Outbox) {
+		o.onDeadLetter = handler
+	}
+}
+
+// New - creates an outbox that stores messages in store and sends them with ms
+func New(ms *mailersend.Mailersend, store Store, opts ...Option) *Outbox {
+	o := &Outbox{
+		client:       ms,
+		store:        store,
+		maxAttempts:  DefaultMaxAttempts,
+		minBackoff:   DefaultMinBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+		pollInterval: DefaultPollInterval,
+		batchSize:    DefaultBatchSize,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// AddEmail - persists message for sending and returns the id of its entry.
+// The IdempotencyKey of the message is kept, the entry id is used when it is empty.
+// Do not modify message afterwards, stores may keep it as is.
+func (o *Outbox) AddEmail(ctx context.Context, message *mailersend.Message) (string, error) {
+	entry, err := o.newEntry(KindEmail, message.IdempotencyKey)
+	if err != nil {
+		return "", err
+	}
+	entry.Message = message
+
+	return entry.ID, o.store.Add(ctx, entry)
+}
+
+// AddSms - persists sms for sending and returns the id of its entry.
+// The IdempotencyKey of the sms is kept, the entry id is used when it is empty.
+// Do not modify sms afterwards, stores may keep it as is.
+func (o *Outbox) AddSms(ctx context.Context, sms *mailersend.Sms) (string, error) {
+	entry, err := o.newEntry(KindSms, sms.IdempotencyKey)
+	if err != nil {
+		return "", err
+	}
+	entry.Sms = sms
+
+	return entry.ID, o.store.Add(ctx, entry)
+}
+
+func (o *Outbox) newEntry(kind Kind, idempotencyKey string) (*Entry, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	if idempotencyKey == "" {
+		idempotencyKey = id
+	}
+
+	now := o.now()
+
+	return &Entry{
+		ID:             id,
+		Kind:           kind,
+		IdempotencyKey: idempotencyKey,
+		Status:         StatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}, nil
+}
+
+// Get - returns the entry with id, its MessageID is set once it was sent
+func (o *Outbox) Get(ctx context.Context, id string) (*Entry, error) {
+	return o.store.Get(ctx, id)
+}
+
+// DeadLetters - returns the entries that failed permanently or ran out of attempts
+func (o *Outbox) DeadLetters(ctx context.Context) ([]*Entry, error) {
+	return o.store.List(ctx, StatusDead)
+}
+
+// Requeue - makes a dead-lettered entry pending again with a fresh set of attempts
+func (o *Outbox) Requeue(ctx context.Context, id string) error {
+	entry, err := o.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if entry.Status != StatusDead {
+		return fmt.Errorf("outbox: entry %s is %s, only dead entries can be requeued", id, entry.Status)
+	}
+
+	entry.Status = StatusPending
+	entry.Attempts = 0
+	entry.NextAttemptAt = o.now()
+
+	return o.store.Update(ctx, entry)
+}
+
+// Purge - deletes the entries sent before the given time and returns how many were deleted
+func (o *Outbox) Purge(ctx context.Context, before time.Time) (int, error) {
+	entries, err := o.store.List(ctx, StatusSent)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, entry := range entries {
+		if !entry.SentAt.Before(before) {
+			continue
+		}
+		if err := o.store.Delete(ctx, entry.ID); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// Run - drains the outbox until ctx is done, then returns ctx.Err().
+// Store errors are logged and retried on the next poll. Run a single worker per store.
+func (o *Outbox) Run(ctx context.Context) error {
+	for {
+		sent, err := o.Drain(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && o.logger != nil {
+			o.logger.Printf("outbox: %v", err)
+		}
+
+		// A full batch means more entries may be due already.
+		if err == nil && sent == o.batchSize {
+			continue
+		}
+
+		timer := time.NewTimer(o.pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Drain - sends the entries that are due, up to the batch size, and returns how many were attempted
+func (o *Outbox) Drain(ctx context.Context) (int, error) {
+	entries, err := o.store.Due(ctx, o.now(), o.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, entry := range entries {
+		if err := o.deliver(ctx, entry); err != nil {
+			return i, err
+		}
+	}
+
+	return len(entries), nil
+}
+
+// deliver sends entry once and records the outcome.
+func (o *Outbox) deliver(ctx context.Context, entry *Entry) error {
+	messageID, err := o.send(ctx, entry)
+	if err != nil && ctx.Err() != nil {
+		// Shutting down, the attempt does not count.
+		return ctx.Err()
+	}
+
+	entry.Attempts++
+	now := o.now()
+
+	switch {
+	case err == nil:
+		entry.Status = StatusSent
+		entry.MessageID = messageID
+		entry.SentAt = now
+		entry.LastError = ""
+	case !mailersend.IsRetryable(err) || entry.Attempts >= o.maxAttempts:
+		entry.Status = StatusDead
+		entry.LastError = err.Error()
+	default:
+		entry.NextAttemptAt = now.Add(o.backoff(entry.Attempts))
+		entry.LastError = err.Error()
+	}
+
+	if err := o.store.Update(ctx, entry); err != nil {
+		return err
+	}
+
+	if entry.Status == StatusDead {
+		if o.logger != nil {
+			o.logger.Printf("outbox: %s %s dead-lettered after %d attempts: %s", entry.Kind, entry.ID, entry.Attempts, entry.LastError)
+		}
+		if o.onDeadLetter != nil {
+			o.onDeadLetter(entry)
+		}
+	}
+
+	return nil
+}
+
+func (o *Outbox) send(ctx context.Context, entry *Entry) (string, error) {
+	switch entry.Kind {
+	case KindEmail:
+		if entry.Message == nil {
+			return "", errInvalidEntry
+		}
+		message := *entry.Message
+		message.IdempotencyKey = entry.IdempotencyKey
+
+		res, err := o.client.Email.Send(ctx, &message)
+		if err != nil {
+			return "", err
+		}
+		return res.Header.Get("X-Message-Id"), nil
+	case KindSms:
+		if entry.Sms == nil {
+			return "", errInvalidEntry
+		}
+		sms := *entry.Sms
+		sms.IdempotencyKey = entry.IdempotencyKey
+
+		res, err := o.client.Sms.Send(ctx, &sms)
+		if err != nil {
+			return "", err
+		}
+		return res.Header.Get("X-SMS-Message-Id"), nil
+	default:
+		return "", errInvalidEntry
+	}
+}
+
+// errInvalidEntry is not retryable, so entries that cannot be sent are dead-lettered.
+var errInvalidEntry = &mailersend.ValidationError{Message: "outbox: entry has no message to send"}
+
+func (o *Outbox) backoff(attempt int) time.Duration {
+	delay := float64(o.minBackoff) * math.Pow(2, float64(attempt-1))
+	if o.maxBackoff > 0 && delay > float64(o.maxBackoff) {
+		delay = float64(o.maxBackoff)
+	}
+
+	return time.Duration(delay)
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("outbox: generating entry id: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,199 @@
+package outbox_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/mailersend/mailersend-go/mailersendtest"
+	"github.com/mailersend/mailersend-go/outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(req *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func message() *mailersend.Message {
+	message := &mailersend.Message{}
+	message.SetFrom(mailersend.From{Email: "sender@example.com"})
+	message.SetRecipients([]mailersend.Recipient{{Email: "user@client.com"}})
+	message.SetSubject("Welcome")
+	message.SetText("Hello")
+
+	return message
+}
+
+func TestOutboxSendsPersistedEmails(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ctx := context.TODO()
+	dir := t.TempDir()
+
+	store, err := outbox.NewFileStore(dir)
+	assert.NoError(t, err)
+
+	id, err := outbox.New(srv.NewMailersend(), store).AddEmail(ctx, message())
+	assert.NoError(t, err)
+
+	// A new process picks up the entries left by the previous one.
+	reopened, err := outbox.NewFileStore(dir)
+	assert.NoError(t, err)
+	box := outbox.New(srv.NewMailersend(), reopened)
+
+	sent, err := box.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	entry, err := box.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, outbox.StatusSent, entry.Status)
+	assert.Equal(t, 1, entry.Attempts)
+	assert.Len(t, srv.SentEmails(), 1)
+	assert.Equal(t, srv.SentEmails()[0].MessageID, entry.MessageID)
+
+	sent, err = box.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, sent)
+
+	purged, err := box.Purge(ctx, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	_, err = box.Get(ctx, id)
+	assert.ErrorIs(t, err, outbox.ErrNotFound)
+}
+
+func TestOutboxRetriesAndDeadLetters(t *testing.T) {
+	var statuses []int
+	var keys []string
+	ms := mailersend.NewMailersend("api-key")
+	ms.SetClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		keys = append(keys, req.Header.Get(mailersend.IdempotencyKeyHeader))
+
+		status := statuses[0]
+		statuses = statuses[1:]
+
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"X-Sms-Message-Id": []string{"sms-id"}},
+			Body:       http.NoBody,
+			Request:    req,
+		}
+	})})
+
+	var dead []string
+	ctx := context.TODO()
+	box := outbox.New(ms, outbox.NewMemoryStore(),
+		outbox.WithMaxAttempts(2),
+		outbox.WithBackoff(0, 0),
+		outbox.WithDeadLetterHandler(func(entry *outbox.Entry) { dead = append(dead, entry.ID) }),
+	)
+
+	sms := &mailersend.Sms{From: "+15550000000", To: []string{"+15551111111"}, Text: "Hello"}
+	sms.IdempotencyKey = "order-1"
+	id, err := box.AddSms(ctx, sms)
+	assert.NoError(t, err)
+
+	statuses = []int{http.StatusServiceUnavailable, http.StatusBadGateway}
+	for i := 0; i < 2; i++ {
+		_, err = box.Drain(ctx)
+		assert.NoError(t, err)
+	}
+
+	entry, err := box.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, outbox.StatusDead, entry.Status)
+	assert.Equal(t, 2, entry.Attempts)
+	assert.Contains(t, entry.LastError, "502")
+	assert.Equal(t, []string{id}, dead)
+
+	letters, err := box.DeadLetters(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+
+	assert.NoError(t, box.Requeue(ctx, id))
+	statuses = []int{http.StatusAccepted}
+	_, err = box.Drain(ctx)
+	assert.NoError(t, err)
+
+	entry, err = box.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, outbox.StatusSent, entry.Status)
+	assert.Equal(t, "sms-id", entry.MessageID)
+	assert.Equal(t, []string{"order-1", "order-1", "order-1"}, keys)
+}
+
+func TestOutboxDoesNotResendAfterUnknownOutcome(t *testing.T) {
+	var calls int
+	ms := mailersend.NewMailersend("api-key")
+	ms.SetIdempotencyStore(mailersend.NewMemoryIdempotencyStore(), time.Hour)
+	ms.SetClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		calls++
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody, Request: req}
+	})})
+
+	ctx := context.TODO()
+	box := outbox.New(ms, outbox.NewMemoryStore(), outbox.WithBackoff(0, 0))
+
+	id, err := box.AddEmail(ctx, message())
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = box.Drain(ctx)
+		assert.NoError(t, err)
+	}
+
+	// The server error may have come after the email was accepted, so it is not sent again.
+	entry, err := box.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, outbox.StatusDead, entry.Status)
+	assert.Equal(t, mailersend.ErrIdempotencyKeyInUse.Error(), entry.LastError)
+	assert.Equal(t, 1, calls)
+}
+
+func TestOutboxDeadLettersInvalidMessages(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	ctx := context.TODO()
+	box := outbox.New(srv.NewMailersend(), outbox.NewMemoryStore())
+
+	invalid := message()
+	invalid.SetRecipients(nil)
+	id, err := box.AddEmail(ctx, invalid)
+	assert.NoError(t, err)
+
+	_, err = box.Drain(ctx)
+	assert.NoError(t, err)
+
+	entry, err := box.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, outbox.StatusDead, entry.Status)
+	assert.Equal(t, 1, entry.Attempts)
+	assert.Empty(t, srv.SentEmails())
+}
+
+func TestOutboxRunStopsWithContext(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	box := outbox.New(srv.NewMailersend(), outbox.NewMemoryStore(), outbox.WithPollInterval(time.Millisecond))
+
+	_, err := box.AddEmail(context.TODO(), message())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- box.Run(ctx) }()
+
+	assert.Eventually(t, func() bool { return len(srv.SentEmails()) == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,179 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+)
+
+// ErrNotFound - the entry is not in the store
+var ErrNotFound = errors.New("outbox: entry not found")
+
+// Kind - what an entry sends
+type Kind string
+
+const (
+	KindEmail Kind = "email"
+	KindSms   Kind = "sms"
+)
+
+// Status - where an entry is in its delivery
+type Status string
+
+const (
+	// StatusPending entries are waiting to be sent or retried.
+	StatusPending Status = "pending"
+	// StatusSent entries were accepted by the API, their MessageID is set.
+	StatusSent Status = "sent"
+	// StatusDead entries failed permanently or ran out of attempts, they are not retried
+	// unless requeued.
+	StatusDead Status = "dead"
+)
+
+// Entry - a message or sms stored in the outbox
+type Entry struct {
+	ID             string              `json:"id"`
+	Kind           Kind                `json:"kind"`
+	Message        *mailersend.Message `json:"message,omitempty"`
+	Sms            *mailersend.Sms     `json:"sms,omitempty"`
+	IdempotencyKey string              `json:"idempotency_key"`
+
+	Status        Status    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+	MessageID     string    `json:"message_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	SentAt        time.Time `json:"sent_at,omitempty"`
+}
+
+// Store - persists the outbox entries.
+// Implementations must be safe for concurrent use and must store copies of the entries
+// they are given, entries are modified after Add and Update return.
+type Store interface {
+	// Add stores a new entry.
+	Add(ctx context.Context, entry *Entry) error
+	// Get returns the entry with id, or ErrNotFound.
+	Get(ctx context.Context, id string) (*Entry, error)
+	// Update replaces the stored entry with the same id, or returns ErrNotFound.
+	Update(ctx context.Context, entry *Entry) error
+	// Delete removes the entry with id.
+	Delete(ctx context.Context, id string) error
+	// Due returns up to limit pending entries whose NextAttemptAt is not after now, oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]*Entry, error)
+	// List returns the entries with status, oldest first.
+	List(ctx context.Context, status Status) ([]*Entry, error)
+}
+
+// MemoryStore - Store kept in memory, for tests and processes that can afford to lose the outbox
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// NewMemoryStore - creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]Entry{}}
+}
+
+// Add - stores a new entry
+func (s *MemoryStore) Add(_ context.Context, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[entry.ID] = *entry
+
+	return nil
+}
+
+// Get - returns the entry with id
+func (s *MemoryStore) Get(_ context.Context, id string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &entry, nil
+}
+
+// Update - replaces the stored entry with the same id
+func (s *MemoryStore) Update(_ context.Context, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[entry.ID]; !ok {
+		return ErrNotFound
+	}
+	s.entries[entry.ID] = *entry
+
+	return nil
+}
+
+// Delete - removes the entry with id
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, id)
+
+	return nil
+}
+
+// Due - returns up to limit pending entries ready to be sent
+func (s *MemoryStore) Due(_ context.Context, now time.Time, limit int) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return filterEntries(s.values(), limit, func(entry *Entry) bool {
+		return entry.Status == StatusPending && !entry.NextAttemptAt.After(now)
+	}), nil
+}
+
+// List - returns the entries with status
+func (s *MemoryStore) List(_ context.Context, status Status) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return filterEntries(s.values(), 0, func(entry *Entry) bool {
+		return entry.Status == status
+	}), nil
+}
+
+func (s *MemoryStore) values() []*Entry {
+	entries := make([]*Entry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entry := entry
+		entries = append(entries, &entry)
+	}
+
+	return entries
+}
+
+// filterEntries returns up to limit entries matching keep, oldest first, limit 0 returns all.
+func filterEntries(entries []*Entry, limit int, keep func(entry *Entry) bool) []*Entry {
+	var matched []*Entry
+	for _, entry := range entries {
+		if keep(entry) {
+			matched = append(matched, entry)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].ID < matched[j].ID
+		}
+		return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+	})
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	return matched
+}
@@ -0,0 +1,102 @@
+package mailersend
+
+import "context"
+
+// Iterator - walks through every item of a paginated endpoint, fetching pages as needed
+//
+//	it := ms.Domain.ListAll(ctx, &mailersend.ListDomainOptions{Limit: 100})
+//	for it.Next() {
+//		domain := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    func(ctx context.Context, page int) ([]T, Links, *Response, error)
+	page     int
+	lastPage bool
+	items    []T
+	item     T
+	response *Response
+	err      error
+	maxItems int
+	count    int
+}
+
+func newIterator[T any](ctx context.Context, page int, fetch func(ctx context.Context, page int) ([]T, Links, *Response, error)) *Iterator[T] {
+	if page < 1 {
+		page = 1
+	}
+
+	return &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		page:  page,
+	}
+}
+
+// SetMaxItems - Set the maximum number of items to return, 0 means no limit
+func (it *Iterator[T]) SetMaxItems(maxItems int) {
+	it.maxItems = maxItems
+}
+
+// Next - advances to the next item, fetching the next page when needed.
+// It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil || (it.maxItems > 0 && it.count >= it.maxItems) {
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.lastPage {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		items, links, res, err := it.fetch(it.ctx, it.page)
+		it.response = res
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = items
+		it.page++
+		it.lastPage = links.Next == "" || len(items) == 0
+	}
+
+	it.item = it.items[0]
+	it.items = it.items[1:]
+	it.count++
+
+	return true
+}
+
+// Item - returns the current item
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err - returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response - returns the response of the last fetched page
+func (it *Iterator[T]) Response() *Response {
+	return it.response
+}
+
+// All - collects the remaining items into a slice
+func (it *Iterator[T]) All() ([]T, error) {
+	var all []T
+	for it.Next() {
+		all = append(all, it.Item())
+	}
+
+	return all, it.Err()
+}
@@ -0,0 +1,105 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// domainPages serves three pages of two domains each.
+func domainPages(t *testing.T, calls *int) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		*calls++
+		page := req.URL.Query().Get("page")
+		assert.Equal(t, "2", req.URL.Query().Get("limit"))
+
+		next := ""
+		if page != "3" {
+			next = "https://api.mailersend.com/v1/domains?page=next"
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{
+				"data": [{"id": "domain-%[1]s-a"}, {"id": "domain-%[1]s-b"}],
+				"links": {"next": %[2]q}
+			}`, page, next))),
+		}
+	})
+}
+
+func TestListAllFollowsPages(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	var calls int
+	ms.SetClient(domainPages(t, &calls))
+
+	it := ms.Domain.ListAll(context.TODO(), &mailersend.ListDomainOptions{Limit: 2})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Item().ID)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"domain-1-a", "domain-1-b", "domain-2-a", "domain-2-b", "domain-3-a", "domain-3-b"}, ids)
+	assert.Equal(t, 3, calls)
+}
+
+func TestListAllRespectsMaxItems(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	var calls int
+	ms.SetClient(domainPages(t, &calls))
+
+	it := ms.Domain.ListAll(context.TODO(), &mailersend.ListDomainOptions{Limit: 2})
+	it.SetMaxItems(3)
+
+	domains, err := it.All()
+
+	assert.NoError(t, err)
+	assert.Len(t, domains, 3)
+	assert.Equal(t, 2, calls)
+}
+
+func TestListAllStopsOnCancel(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	var calls int
+	ms.SetClient(domainPages(t, &calls))
+
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	it := ms.Domain.ListAll(ctx, &mailersend.ListDomainOptions{Limit: 2})
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+
+	cancel()
+
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestListAllReturnsErrors(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusUnauthorized,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"message": "Unauthenticated."}`)),
+		}
+	}))
+
+	it := ms.Suppression.ListAllHardBounces(context.TODO(), nil)
+
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+	assert.Equal(t, http.StatusUnauthorized, it.Response().StatusCode)
+}
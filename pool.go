@@ -0,0 +1,104 @@
+package mailersend
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownTenant - the tenant was not added to the ClientPool
+var ErrUnknownTenant = errors.New("mailersend: unknown tenant")
+
+// ClientPool - hands out one client per tenant, each with its own api token.
+//
+// All clients are created with the options of the pool, so they share the http client
+// and its transport. Every tenant has its own RateLimiter, which follows the rate limit
+// headers of the responses to that tenant's token and survives token rotation.
+//
+// Clients are never modified once handed out, rotating a token replaces the tenant's
+// client, so get the client from the pool for every operation rather than keeping it.
+type ClientPool struct {
+	mu      sync.RWMutex
+	opts    []Option
+	tenants map[string]*poolTenant
+}
+
+type poolTenant struct {
+	client  *Mailersend
+	limiter *RateLimiter
+}
+
+// NewClientPool - creates an empty pool whose clients are configured by opts
+func NewClientPool(opts ...Option) *ClientPool {
+	return &ClientPool{
+		opts:    opts,
+		tenants: map[string]*poolTenant{},
+	}
+}
+
+// Set - Add a tenant, or rotate its api token.
+// In-flight requests finish with the previous token, new ones use apiKey.
+func (p *ClientPool) Set(tenant string, apiKey string) *Mailersend {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.tenants[tenant]
+	if !ok {
+		entry = &poolTenant{limiter: NewRateLimiter(DefaultRequestsPerMinute)}
+		p.tenants[tenant] = entry
+	}
+
+	opts := append(append([]Option{}, p.opts...), WithRateLimiter(entry.limiter))
+	entry.client = NewMailersend(apiKey, opts...)
+
+	return entry.client
+}
+
+// Get - returns the client of tenant, or ErrUnknownTenant
+func (p *ClientPool) Get(tenant string) (*Mailersend, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	entry, ok := p.tenants[tenant]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTenant, tenant)
+	}
+
+	return entry.client, nil
+}
+
+// RateLimiter - returns the limiter of tenant, to seed its quota or make it fail fast
+func (p *ClientPool) RateLimiter(tenant string) (*RateLimiter, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	entry, ok := p.tenants[tenant]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTenant, tenant)
+	}
+
+	return entry.limiter, nil
+}
+
+// Remove - Remove a tenant and its rate limit state
+func (p *ClientPool) Remove(tenant string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.tenants, tenant)
+}
+
+// Tenants - returns the sorted names of the tenants in the pool
+func (p *ClientPool) Tenants() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	tenants := make([]string, 0, len(p.tenants))
+	for tenant := range p.tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	return tenants
+}
@@ -0,0 +1,68 @@
+package mailersend_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientPool(t *testing.T) {
+	var mu sync.Mutex
+	tokens := map[string]int{}
+
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		mu.Lock()
+		tokens[strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")]++
+		mu.Unlock()
+
+		return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody, Header: http.Header{"X-Ratelimit-Limit": []string{"120"}}}
+	})
+
+	pool := mailersend.NewClientPool(mailersend.WithHTTPClient(client))
+	pool.Set("acme", "acme-key")
+	pool.Set("globex", "globex-key")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, tenant := range pool.Tenants() {
+			wg.Add(1)
+			go func(tenant string) {
+				defer wg.Done()
+
+				ms, err := pool.Get(tenant)
+				assert.NoError(t, err)
+				assert.Same(t, client, ms.Client())
+
+				_, err = ms.Email.Send(context.TODO(), basicEmailNew())
+				assert.NoError(t, err)
+			}(tenant)
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[string]int{"acme-key": 10, "globex-key": 10}, tokens)
+
+	limiter, err := pool.RateLimiter("acme")
+	assert.NoError(t, err)
+
+	rotated := pool.Set("acme", "acme-key-2")
+	assert.Equal(t, "acme-key-2", rotated.APIKey())
+
+	ms, err := pool.Get("acme")
+	assert.NoError(t, err)
+	assert.Same(t, rotated, ms)
+
+	rotatedLimiter, err := pool.RateLimiter("acme")
+	assert.NoError(t, err)
+	assert.Same(t, limiter, rotatedLimiter)
+
+	pool.Remove("globex")
+	_, err = pool.Get("globex")
+	assert.ErrorIs(t, err, mailersend.ErrUnknownTenant)
+	assert.Equal(t, []string{"acme"}, pool.Tenants())
+}
@@ -0,0 +1,226 @@
+package mailersend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute - the general API rate limit used until the API reports another one
+const DefaultRequestsPerMinute = 60
+
+// ThrottledError - returned instead of sending a request when the rate limiter is in fail fast mode
+// and the request would exceed the rate limit or the daily quota.
+type ThrottledError struct {
+	// Wait is how long until a request would be allowed again.
+	Wait time.Duration
+	// Daily reports whether the daily quota is exhausted rather than the per-minute limit.
+	Daily bool
+}
+
+func (e *ThrottledError) Error() string {
+	if e.Daily {
+		return fmt.Sprintf("mailersend: daily quota exhausted, resets in %v", e.Wait)
+	}
+	return fmt.Sprintf("mailersend: rate limit reached, retry in %v", e.Wait)
+}
+
+// RateLimiter - client side token buckets that keep requests within the API rate limits.
+//
+// Sending emails and the rest of the API have separate rate limits, so each has its own bucket.
+// The bucket size follows the X-RateLimit-Limit header of responses in its scope and the remaining
+// tokens never exceed X-RateLimit-Remaining. A daily budget can be seeded from ApiQuotaService.Get
+// with SeedQuota. By default callers block until a request is allowed, SetFailFast makes them
+// return a *ThrottledError instead.
+type RateLimiter struct {
+	mu         sync.Mutex
+	buckets    [rateLimitScopes]rateBucket
+	daily      int
+	dailyReset time.Time
+	failFast   bool
+	now        func() time.Time
+}
+
+// rateLimitScope is a group of endpoints sharing one rate limit.
+type rateLimitScope int
+
+const (
+	generalScope rateLimitScope = iota
+	emailScope
+	rateLimitScopes
+)
+
+// rateLimitScopeOf returns emailScope for sending emails and generalScope for everything else.
+func rateLimitScopeOf(req *http.Request) rateLimitScope {
+	if req == nil || req.Method != http.MethodPost {
+		return generalScope
+	}
+	if strings.HasSuffix(req.URL.Path, emailBasePath) || strings.HasSuffix(req.URL.Path, bulkEmailBasePath) {
+		return emailScope
+	}
+
+	return generalScope
+}
+
+type rateBucket struct {
+	limit        int
+	tokens       float64
+	updated      time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter - creates a limiter allowing requestsPerMinute in each scope until the API reports
+// its limits, DefaultRequestsPerMinute when 0
+func NewRateLimiter(requestsPerMinute int) *RateLimiter {
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = DefaultRequestsPerMinute
+	}
+
+	l := &RateLimiter{
+		daily: -1,
+		now:   time.Now,
+	}
+	for i := range l.buckets {
+		l.buckets[i] = rateBucket{
+			limit:   requestsPerMinute,
+			tokens:  float64(requestsPerMinute),
+			updated: l.now(),
+		}
+	}
+
+	return l
+}
+
+// SetFailFast - Set whether Wait returns a *ThrottledError instead of blocking
+func (l *RateLimiter) SetFailFast(failFast bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.failFast = failFast
+}
+
+// SetDailyQuota - Set the number of requests left until reset
+func (l *RateLimiter) SetDailyQuota(remaining int, reset time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.daily = remaining
+	l.dailyReset = reset
+}
+
+// SeedQuota - Set the daily quota from the API quota endpoint
+func (l *RateLimiter) SeedQuota(ctx context.Context, quota ApiQuotaService) error {
+	root, _, err := quota.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	l.SetDailyQuota(root.Remaining, root.Reset)
+
+	return nil
+}
+
+// Wait - blocks until a general API request is allowed, or returns a *ThrottledError in fail fast mode
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	return l.wait(ctx, generalScope)
+}
+
+// WaitRequest - like Wait, for the rate limit scope of req
+func (l *RateLimiter) WaitRequest(req *http.Request) error {
+	return l.wait(req.Context(), rateLimitScopeOf(req))
+}
+
+func (l *RateLimiter) wait(ctx context.Context, scope rateLimitScope) error {
+	for {
+		l.mu.Lock()
+		wait, daily := l.reserve(scope)
+		failFast := l.failFast
+		l.mu.Unlock()
+
+		if wait <= 0 {
+			return nil
+		}
+		if failFast {
+			return &ThrottledError{Wait: wait, Daily: daily}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token of scope and returns 0, or returns how long to wait for one. l.mu must be held.
+func (l *RateLimiter) reserve(scope rateLimitScope) (time.Duration, bool) {
+	now := l.now()
+	b := &l.buckets[scope]
+
+	if l.daily >= 0 && !now.Before(l.dailyReset) {
+		l.daily = -1
+	}
+	if l.daily == 0 {
+		return l.dailyReset.Sub(now), true
+	}
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now), false
+	}
+
+	rate := float64(b.limit) / float64(time.Minute)
+	b.tokens += float64(now.Sub(b.updated)) * rate
+	if b.tokens > float64(b.limit) {
+		b.tokens = float64(b.limit)
+	}
+	b.updated = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate), false
+	}
+
+	b.tokens--
+	if l.daily > 0 {
+		l.daily--
+	}
+
+	return 0, false
+}
+
+// Update - learns the current limits of the scope of resp.Request from the X-RateLimit headers of resp
+func (l *RateLimiter) Update(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	l.update(rateLimitScopeOf(resp.Request), resp)
+}
+
+func (l *RateLimiter) update(scope rateLimitScope, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := &l.buckets[scope]
+	if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil && limit > 0 {
+		b.limit = limit
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil && float64(remaining) < b.tokens {
+		b.tokens = float64(remaining)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		b.tokens = 0
+		if after, ok := retryAfter(resp.Header, l.now()); ok {
+			b.blockedUntil = l.now().Add(after)
+		}
+	}
+}
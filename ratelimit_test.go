@@ -0,0 +1,142 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterFailsFast(t *testing.T) {
+	limiter := mailersend.NewRateLimiter(2)
+	limiter.SetFailFast(true)
+
+	ctx := context.TODO()
+
+	assert.NoError(t, limiter.Wait(ctx))
+	assert.NoError(t, limiter.Wait(ctx))
+
+	err := limiter.Wait(ctx)
+
+	var throttled *mailersend.ThrottledError
+	assert.True(t, errors.As(err, &throttled))
+	assert.False(t, throttled.Daily)
+	assert.Greater(t, throttled.Wait, time.Duration(0))
+}
+
+func TestRateLimiterBlocksUntilContextDone(t *testing.T) {
+	limiter := mailersend.NewRateLimiter(1)
+
+	assert.NoError(t, limiter.Wait(context.TODO()))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimiterLearnsFromHeaders(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	limiter := mailersend.NewRateLimiter(60)
+	limiter.SetFailFast(true)
+	ms.SetRateLimiter(limiter)
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"X-Ratelimit-Limit":     []string{"60"},
+				"X-Ratelimit-Remaining": []string{"0"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(`{"data": []}`)),
+		}
+	}))
+
+	ctx := context.TODO()
+
+	_, _, err := ms.Domain.List(ctx, nil)
+	assert.NoError(t, err)
+
+	_, _, err = ms.Domain.List(ctx, nil)
+
+	var throttled *mailersend.ThrottledError
+	assert.True(t, errors.As(err, &throttled))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRateLimiterKeepsEmailAndGeneralLimitsApart(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	limiter := mailersend.NewRateLimiter(60)
+	limiter.SetFailFast(true)
+	ms.SetRateLimiter(limiter)
+
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		header := http.Header{"X-Ratelimit-Limit": []string{"60"}, "X-Ratelimit-Remaining": []string{"59"}}
+		if req.URL.Path == "/v1/email" {
+			header = http.Header{"X-Ratelimit-Limit": []string{"10"}, "X-Ratelimit-Remaining": []string{"0"}}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Header:     header,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": []}`)),
+		}
+	}))
+
+	ctx := context.TODO()
+
+	_, err := ms.Email.Send(ctx, basicEmail())
+	assert.NoError(t, err)
+
+	_, _, err = ms.Domain.List(ctx, nil)
+	assert.NoError(t, err)
+
+	_, err = ms.Email.Send(ctx, basicEmail())
+
+	var throttled *mailersend.ThrottledError
+	assert.True(t, errors.As(err, &throttled))
+
+	_, _, err = ms.Domain.List(ctx, nil)
+	assert.NoError(t, err)
+}
+
+func TestRateLimiterSeedsDailyQuota(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+
+	limiter := mailersend.NewRateLimiter(60)
+	limiter.SetFailFast(true)
+	ms.SetRateLimiter(limiter)
+
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "https://api.mailersend.com/v1/api-quota", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(bytes.NewBufferString(`{
+				"quota": 100,
+				"remaining": 1,
+				"reset": "` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"
+			}`)),
+		}
+	}))
+
+	ctx := context.TODO()
+
+	assert.NoError(t, limiter.SeedQuota(ctx, ms.ApiQuota))
+	assert.NoError(t, limiter.Wait(ctx))
+
+	err := limiter.Wait(ctx)
+
+	var throttled *mailersend.ThrottledError
+	assert.True(t, errors.As(err, &throttled))
+	assert.True(t, throttled.Daily)
+}
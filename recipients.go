@@ -10,6 +10,7 @@ const recipientBasePath = "/recipients"
 
 type RecipientService interface {
 	List(ctx context.Context, options *ListRecipientOptions) (*RecipientRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListRecipientOptions) *Iterator[RecipientObject]
 	Get(ctx context.Context, recipientID string) (*SingleRecipientRoot, *Response, error)
 	Delete(ctx context.Context, recipientID string) (*Response, error)
 }
@@ -63,7 +64,7 @@ func (s *recipientService) List(ctx context.Context, options *ListRecipientOptio
 	}
 
 	root := new(RecipientRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Recipient.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -71,6 +72,24 @@ func (s *recipientService) List(ctx context.Context, options *ListRecipientOptio
 	return root, res, nil
 }
 
+func (s *recipientService) ListAll(ctx context.Context, options *ListRecipientOptions) *Iterator[RecipientObject] {
+	opts := ListRecipientOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]RecipientObject, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *recipientService) Get(ctx context.Context, recipientID string) (*SingleRecipientRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", recipientBasePath, recipientID)
 
@@ -80,7 +99,7 @@ func (s *recipientService) Get(ctx context.Context, recipientID string) (*Single
 	}
 
 	root := new(SingleRecipientRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Recipient.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -96,6 +115,6 @@ func (s *recipientService) Delete(ctx context.Context, recipientID string) (*Res
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Recipient.Delete", req, nil)
 
 }
@@ -0,0 +1,220 @@
+package mailersend
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// IdempotencyKeyHeader - header carrying the idempotency key of a request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultMaxRetryAfter - the longest delay requested by the API that is waited for, when RetryPolicy.MaxRetryAfter is 0
+const DefaultMaxRetryAfter = time.Minute
+
+// RetryPolicy - controls how requests that failed with a connection error, 429 or 5xx are retried.
+//
+// GET, PUT and DELETE requests are always retried. POST requests are only retried when it
+// is safe to do so: on 429, when the connection failed before the request was written, or
+// when the request carries an idempotency key.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one, values below 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, it doubles on every further retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed delay.
+	MaxBackoff time.Duration
+	// MaxRetryAfter caps delays requested by the API through Retry-After or X-RateLimit-Reset,
+	// DefaultMaxRetryAfter when 0. When the API asks for a longer delay, or one past the context
+	// deadline, the response is returned without retrying, so IsRateLimited callers can decide
+	// what to do, for example when the daily quota is exhausted.
+	MaxRetryAfter time.Duration
+	// Jitter randomly shortens each computed delay by up to this fraction, between 0 and 1.
+	Jitter float64
+}
+
+// DefaultRetryPolicy - returns a policy suitable for most applications
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey - returns a context that makes requests sent with it carry key
+// in the Idempotency-Key header, which also makes POST requests safe to retry.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+func (p *RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxRetryAfter > 0 {
+		return p.MaxRetryAfter
+	}
+	return DefaultMaxRetryAfter
+}
+
+// backoff returns the delay before retry number attempt, starting at 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.MinBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay -= delay * math.Min(p.Jitter, 1) * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// sendWithRetry sends req through the http client, throttled by the rate limiter and retried
+// according to the retry policy.
+func (state *clientState) sendWithRetry(req *http.Request) (*http.Response, error) {
+	policy := state.retryPolicy
+	ctx := req.Context()
+	info := callInfoFromContext(ctx)
+	scope := rateLimitScopeOf(req)
+
+	for attempt := 1; ; attempt++ {
+		if state.rateLimiter != nil {
+			if err := state.rateLimiter.wait(ctx, scope); err != nil {
+				return nil, err
+			}
+		}
+		if info != nil {
+			atomic.AddInt32(&info.attempts, 1)
+		}
+
+		var wrote int32
+		trace := &httptrace.ClientTrace{
+			WroteRequest: func(httptrace.WroteRequestInfo) { atomic.StoreInt32(&wrote, 1) },
+		}
+		resp, err := state.client.Do(req.WithContext(httptrace.WithClientTrace(ctx, trace)))
+		if state.rateLimiter != nil {
+			state.rateLimiter.update(scope, resp)
+		}
+
+		if policy == nil || attempt >= policy.MaxAttempts ||
+			!shouldRetry(req, resp, err, atomic.LoadInt32(&wrote) == 1) {
+			return resp, err
+		}
+
+		delay := policy.backoff(attempt)
+		if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+			if after, ok := retryAfter(resp.Header, time.Now()); ok {
+				if after > policy.maxRetryAfter() {
+					return resp, err
+				}
+				delay = after
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+
+		body, rewindErr := rewindBody(req)
+		if rewindErr != nil {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		req.Body = body
+	}
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error, wrote bool) bool {
+	if err != nil {
+		if req.Context().Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return isIdempotent(req) || !wrote
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// Rate limited requests are rejected before they are processed.
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	}
+
+	return false
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+func rewindBody(req *http.Request) (io.ReadCloser, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req.Body, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("mailersend: request body cannot be replayed")
+	}
+	return req.GetBody()
+}
+
+// retryAfter returns the delay requested by the Retry-After or X-RateLimit-Reset headers.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return clampDelay(time.Duration(seconds) * time.Second), true
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return clampDelay(at.Sub(now)), true
+		}
+	}
+
+	if v := h.Get("X-RateLimit-Reset"); v != "" {
+		if reset, err := strconv.ParseInt(v, 10, 64); err == nil {
+			// Small values are relative seconds, anything else is a unix timestamp.
+			if reset < 1e9 {
+				return clampDelay(time.Duration(reset) * time.Second), true
+			}
+			return clampDelay(time.Unix(reset, 0).Sub(now)), true
+		}
+	}
+
+	return 0, false
+}
+
+func clampDelay(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,210 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type errorTransport struct {
+	calls int
+	err   error
+}
+
+func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	return nil, t.err
+}
+
+func testRetryPolicy() *mailersend.RetryPolicy {
+	return &mailersend.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestRetriesIdempotentRequests(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(testRetryPolicy())
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		if calls < 3 {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "unavailable"}`)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": {"id": "domain-id"}}`)),
+		}
+	}))
+
+	root, res, err := ms.Domain.Get(context.TODO(), "domain-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "domain-id", root.Data.ID)
+	assert.Equal(t, 3, calls)
+}
+
+func TestGivesUpAfterMaxAttempts(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(testRetryPolicy())
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"message": "bad gateway"}`)),
+		}
+	}))
+
+	_, res, err := ms.Domain.Get(context.TODO(), "domain-id")
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+	assert.Equal(t, 3, calls)
+}
+
+func TestDoesNotRetryServerErrorsForSend(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(testRetryPolicy())
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"message": "error"}`)),
+		}
+	}))
+
+	_, err := ms.Email.Send(context.TODO(), basicEmailNew())
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetriesSendWithIdempotencyKey(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(testRetryPolicy())
+
+	var bodies []string
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, "key-1", req.Header.Get(mailersend.IdempotencyKeyHeader))
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "error"}`)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       io.NopCloser(bytes.NewBufferString(``)),
+		}
+	}))
+
+	ctx := mailersend.WithIdempotencyKey(context.TODO(), "key-1")
+	res, err := ms.Email.Send(ctx, basicEmailNew())
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	assert.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestRetriesSendOnConnectionErrorBeforeWrite(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(testRetryPolicy())
+
+	transport := &errorTransport{err: errors.New("connection refused")}
+	ms.SetClient(&http.Client{Transport: transport})
+
+	_, err := ms.Email.Send(context.TODO(), basicEmailNew())
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, transport.calls)
+}
+
+func TestHonorsRetryAfter(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(testRetryPolicy())
+
+	var calls []time.Time
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls = append(calls, time.Now())
+		if len(calls) == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"1"}},
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "Too Many Attempts."}`)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       io.NopCloser(bytes.NewBufferString(``)),
+		}
+	}))
+
+	_, err := ms.Email.Send(context.TODO(), basicEmailNew())
+
+	assert.NoError(t, err)
+	assert.Len(t, calls, 2)
+	assert.GreaterOrEqual(t, calls[1].Sub(calls[0]), time.Second)
+}
+
+func TestDoesNotWaitForLongRetryAfter(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(mailersend.DefaultRetryPolicy())
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"36000"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`{"message": "Daily quota exceeded."}`)),
+		}
+	}))
+
+	start := time.Now()
+	_, err := ms.Email.Send(context.Background(), basicEmailNew())
+
+	assert.True(t, mailersend.IsRateLimited(err))
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDoesNotWaitPastContextDeadline(t *testing.T) {
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetRetryPolicy(testRetryPolicy())
+
+	var calls int
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"60"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`{"message": "Too Many Attempts."}`)),
+		}
+	}))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	_, res, err := ms.Domain.Get(ctx, "domain-id")
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, 1, calls)
+}
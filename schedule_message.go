@@ -11,6 +11,7 @@ const messageScheduleBasePath = "/message-schedules"
 
 type ScheduleMessageService interface {
 	List(ctx context.Context, options *ListScheduleMessageOptions) (*ScheduleMessageRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListScheduleMessageOptions) *Iterator[ScheduleMessageData]
 	Get(ctx context.Context, messageID string) (*ScheduleMessageSingleRoot, *Response, error)
 	Delete(ctx context.Context, messageID string) (*Response, error)
 }
@@ -77,7 +78,7 @@ func (s *scheduleMessageService) List(ctx context.Context, options *ListSchedule
 	}
 
 	root := new(ScheduleMessageRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "ScheduleMessage.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -85,6 +86,24 @@ func (s *scheduleMessageService) List(ctx context.Context, options *ListSchedule
 	return root, res, nil
 }
 
+func (s *scheduleMessageService) ListAll(ctx context.Context, options *ListScheduleMessageOptions) *Iterator[ScheduleMessageData] {
+	opts := ListScheduleMessageOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]ScheduleMessageData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *scheduleMessageService) Get(ctx context.Context, messageID string) (*ScheduleMessageSingleRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", messageScheduleBasePath, messageID)
 
@@ -94,7 +113,7 @@ func (s *scheduleMessageService) Get(ctx context.Context, messageID string) (*Sc
 	}
 
 	root := new(ScheduleMessageSingleRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "ScheduleMessage.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -110,5 +129,5 @@ func (s *scheduleMessageService) Delete(ctx context.Context, messageID string) (
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "ScheduleMessage.Delete", req, nil)
 }
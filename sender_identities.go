@@ -11,6 +11,7 @@ const identitiesBasePath = "/identities"
 
 type IdentityService interface {
 	List(ctx context.Context, options *ListIdentityOptions) (*IdentityRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListIdentityOptions) *Iterator[Identity]
 	Get(ctx context.Context, identityID string) (*SingleIdentityRoot, *Response, error)
 	GetByEmail(ctx context.Context, identityEmail string) (*SingleIdentityRoot, *Response, error)
 	Create(ctx context.Context, options *CreateIdentityOptions) (*SingleIdentityRoot, *Response, error)
@@ -83,7 +84,7 @@ func (s *identityService) List(ctx context.Context, options *ListIdentityOptions
 	}
 
 	root := new(IdentityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Identity.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -91,6 +92,24 @@ func (s *identityService) List(ctx context.Context, options *ListIdentityOptions
 	return root, res, nil
 }
 
+func (s *identityService) ListAll(ctx context.Context, options *ListIdentityOptions) *Iterator[Identity] {
+	opts := ListIdentityOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]Identity, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *identityService) Get(ctx context.Context, identityID string) (*SingleIdentityRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", identitiesBasePath, identityID)
 
@@ -100,7 +119,7 @@ func (s *identityService) Get(ctx context.Context, identityID string) (*SingleId
 	}
 
 	root := new(SingleIdentityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Identity.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -117,7 +136,7 @@ func (s *identityService) GetByEmail(ctx context.Context, identityEmail string)
 	}
 
 	root := new(SingleIdentityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Identity.GetByEmail", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -132,7 +151,7 @@ func (s *identityService) Create(ctx context.Context, options *CreateIdentityOpt
 	}
 
 	root := new(SingleIdentityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Identity.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -149,7 +168,7 @@ func (s *identityService) Update(ctx context.Context, identityID string, options
 	}
 
 	root := new(SingleIdentityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Identity.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -166,7 +185,7 @@ func (s *identityService) UpdateByEmail(ctx context.Context, identityEmail strin
 	}
 
 	root := new(SingleIdentityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Identity.UpdateByEmail", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -182,7 +201,7 @@ func (s *identityService) Delete(ctx context.Context, identityID string) (*Respo
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Identity.Delete", req, nil)
 }
 
 func (s *identityService) DeleteByEmail(ctx context.Context, identityEmail string) (*Response, error) {
@@ -193,5 +212,5 @@ func (s *identityService) DeleteByEmail(ctx context.Context, identityEmail strin
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Identity.DeleteByEmail", req, nil)
 }
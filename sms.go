@@ -22,6 +22,9 @@ type Sms struct {
 	To              []string             `json:"to"`
 	Text            string               `json:"text"`
 	Personalization []SmsPersonalization `json:"personalization,omitempty"`
+
+	// IdempotencyKey is sent in the Idempotency-Key header, see SetIdempotencyStore.
+	IdempotencyKey string `json:"-"`
 }
 
 // SmsPersonalization - you can set multiple SmsPersonalization for each Recipient
@@ -88,5 +91,6 @@ func (s *smsService) Send(ctx context.Context, sms *Sms) (*Response, error) {
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	res, _, err := s.client.sendOnce(ctx, "Sms.Send", "sms", sms.IdempotencyKey, smsMessageIDHeader, req, nil)
+	return res, err
 }
@@ -11,6 +11,7 @@ const smsActivityPath = "/sms-activity"
 
 type SmsActivityService interface {
 	List(ctx context.Context, options *SmsActivityOptions) (*SmsListActivityRoot, *Response, error)
+	ListAll(ctx context.Context, options *SmsActivityOptions) *Iterator[SmsActivityData]
 	Get(ctx context.Context, smsMessageID string) (*SmsMessageRoot, *Response, error)
 }
 
@@ -51,7 +52,7 @@ func (s *smsActivityService) List(ctx context.Context, options *SmsActivityOptio
 	}
 
 	root := new(SmsListActivityRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsActivity.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -59,6 +60,24 @@ func (s *smsActivityService) List(ctx context.Context, options *SmsActivityOptio
 	return root, res, nil
 }
 
+func (s *smsActivityService) ListAll(ctx context.Context, options *SmsActivityOptions) *Iterator[SmsActivityData] {
+	opts := SmsActivityOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SmsActivityData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *smsActivityService) Get(ctx context.Context, smsMessageID string) (*SmsMessageRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", smsMessagesPath, smsMessageID)
 
@@ -68,7 +87,7 @@ func (s *smsActivityService) Get(ctx context.Context, smsMessageID string) (*Sms
 	}
 
 	root := new(SmsMessageRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsActivity.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -11,6 +11,7 @@ const smsInboundPath = "/sms-inbounds"
 
 type SmsInboundService interface {
 	List(ctx context.Context, options *ListSmsInboundOptions) (*SmsInboundRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListSmsInboundOptions) *Iterator[SmsInbound]
 	Get(ctx context.Context, smsInboundId string) (*SingleSmsInboundRoot, *Response, error)
 	Create(ctx context.Context, options *CreateSmsInboundOptions) (*SingleSmsInboundRoot, *Response, error)
 	Update(ctx context.Context, options *UpdateSmsInboundOptions) (*SingleSmsInboundRoot, *Response, error)
@@ -77,7 +78,7 @@ func (s *smsInboundService) List(ctx context.Context, options *ListSmsInboundOpt
 	}
 
 	root := new(SmsInboundRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsInbound.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -85,6 +86,24 @@ func (s *smsInboundService) List(ctx context.Context, options *ListSmsInboundOpt
 	return root, res, nil
 }
 
+func (s *smsInboundService) ListAll(ctx context.Context, options *ListSmsInboundOptions) *Iterator[SmsInbound] {
+	opts := ListSmsInboundOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SmsInbound, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *smsInboundService) Get(ctx context.Context, smsInboundId string) (*SingleSmsInboundRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", smsInboundPath, smsInboundId)
 
@@ -94,7 +113,7 @@ func (s *smsInboundService) Get(ctx context.Context, smsInboundId string) (*Sing
 	}
 
 	root := new(SingleSmsInboundRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsInbound.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -109,7 +128,7 @@ func (s *smsInboundService) Create(ctx context.Context, options *CreateSmsInboun
 	}
 
 	root := new(SingleSmsInboundRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsInbound.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -126,7 +145,7 @@ func (s *smsInboundService) Update(ctx context.Context, options *UpdateSmsInboun
 	}
 
 	root := new(SingleSmsInboundRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsInbound.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -142,5 +161,5 @@ func (s *smsInboundService) Delete(ctx context.Context, smsInboundId string) (*R
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "SmsInbound.Delete", req, nil)
 }
@@ -10,6 +10,7 @@ const smsMessagesPath = "/sms-messages"
 
 type SmsMessageService interface {
 	List(ctx context.Context, options *ListSmsMessageOptions) (*SmsListMessagesRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListSmsMessageOptions) *Iterator[SmsMessageData]
 	Get(ctx context.Context, smsMessageID string) (*SmsSingleMessagesRoot, *Response, error)
 }
 
@@ -40,7 +41,7 @@ func (s *smsMessageService) List(ctx context.Context, options *ListSmsMessageOpt
 	}
 
 	root := new(SmsListMessagesRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsMessage.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -48,6 +49,24 @@ func (s *smsMessageService) List(ctx context.Context, options *ListSmsMessageOpt
 	return root, res, nil
 }
 
+func (s *smsMessageService) ListAll(ctx context.Context, options *ListSmsMessageOptions) *Iterator[SmsMessageData] {
+	opts := ListSmsMessageOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SmsMessageData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *smsMessageService) Get(ctx context.Context, smsMessageID string) (*SmsSingleMessagesRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", smsMessagesPath, smsMessageID)
 
@@ -57,7 +76,7 @@ func (s *smsMessageService) Get(ctx context.Context, smsMessageID string) (*SmsS
 	}
 
 	root := new(SmsSingleMessagesRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsMessage.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
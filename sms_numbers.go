@@ -11,6 +11,7 @@ const smsNumbersPath = "/sms-numbers"
 
 type SmsNumberService interface {
 	List(ctx context.Context, options *SmsNumberOptions) (*SmsNumberRoot, *Response, error)
+	ListAll(ctx context.Context, options *SmsNumberOptions) *Iterator[Number]
 	Get(ctx context.Context, numberID string) (*SingleSmsNumberRoot, *Response, error)
 	Update(ctx context.Context, options *SmsNumberSettingOptions) (*SingleSmsNumberRoot, *Response, error)
 	Delete(ctx context.Context, numberID string) (*Response, error)
@@ -59,7 +60,7 @@ func (s *smsNumberService) List(ctx context.Context, options *SmsNumberOptions)
 	}
 
 	root := new(SmsNumberRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsNumber.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -67,6 +68,24 @@ func (s *smsNumberService) List(ctx context.Context, options *SmsNumberOptions)
 	return root, res, nil
 }
 
+func (s *smsNumberService) ListAll(ctx context.Context, options *SmsNumberOptions) *Iterator[Number] {
+	opts := SmsNumberOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]Number, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *smsNumberService) Get(ctx context.Context, numberID string) (*SingleSmsNumberRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", smsNumbersPath, numberID)
 
@@ -76,7 +95,7 @@ func (s *smsNumberService) Get(ctx context.Context, numberID string) (*SingleSms
 	}
 
 	root := new(SingleSmsNumberRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsNumber.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -93,7 +112,7 @@ func (s *smsNumberService) Update(ctx context.Context, options *SmsNumberSetting
 	}
 
 	root := new(SingleSmsNumberRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsNumber.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -109,5 +128,5 @@ func (s *smsNumberService) Delete(ctx context.Context, numberID string) (*Respon
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "SmsNumber.Delete", req, nil)
 }
@@ -11,6 +11,7 @@ const smsRecipientPath = "/sms-recipients"
 
 type SmsRecipientService interface {
 	List(ctx context.Context, options *SmsRecipientOptions) (*SmsRecipientRoot, *Response, error)
+	ListAll(ctx context.Context, options *SmsRecipientOptions) *Iterator[SmsRecipient]
 	Get(ctx context.Context, smsRecipientId string) (*SingleSmsRecipientRoot, *Response, error)
 	Update(ctx context.Context, options *SmsRecipientSettingOptions) (*SingleSmsRecipientUpdateRoot, *Response, error)
 }
@@ -79,7 +80,7 @@ func (s *smsRecipientService) List(ctx context.Context, options *SmsRecipientOpt
 	}
 
 	root := new(SmsRecipientRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsRecipient.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -87,6 +88,24 @@ func (s *smsRecipientService) List(ctx context.Context, options *SmsRecipientOpt
 	return root, res, nil
 }
 
+func (s *smsRecipientService) ListAll(ctx context.Context, options *SmsRecipientOptions) *Iterator[SmsRecipient] {
+	opts := SmsRecipientOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SmsRecipient, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *smsRecipientService) Get(ctx context.Context, smsRecipientId string) (*SingleSmsRecipientRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", smsRecipientPath, smsRecipientId)
 
@@ -96,7 +115,7 @@ func (s *smsRecipientService) Get(ctx context.Context, smsRecipientId string) (*
 	}
 
 	root := new(SingleSmsRecipientRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsRecipient.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -113,7 +132,7 @@ func (s *smsRecipientService) Update(ctx context.Context, options *SmsRecipientS
 	}
 
 	root := new(SingleSmsRecipientUpdateRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsRecipient.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -11,6 +11,7 @@ const smsWebhookPath = "/sms-webhooks"
 
 type SmsWebhookService interface {
 	List(ctx context.Context, options *ListSmsWebhookOptions) (*SmsWebhookRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListSmsWebhookOptions) *Iterator[SmsWebhook]
 	Get(ctx context.Context, smsWebhookId string) (*SingleSmsWebhookRoot, *Response, error)
 	Create(ctx context.Context, options *CreateSmsWebhookOptions) (*SingleSmsWebhookRoot, *Response, error)
 	Update(ctx context.Context, options *UpdateSmsWebhookOptions) (*SingleSmsWebhookRoot, *Response, error)
@@ -77,7 +78,7 @@ func (s *smsWebhookService) List(ctx context.Context, options *ListSmsWebhookOpt
 	}
 
 	root := new(SmsWebhookRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsWebhook.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -85,6 +86,24 @@ func (s *smsWebhookService) List(ctx context.Context, options *ListSmsWebhookOpt
 	return root, res, nil
 }
 
+func (s *smsWebhookService) ListAll(ctx context.Context, options *ListSmsWebhookOptions) *Iterator[SmsWebhook] {
+	opts := ListSmsWebhookOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SmsWebhook, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *smsWebhookService) Get(ctx context.Context, smsWebhookId string) (*SingleSmsWebhookRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", smsWebhookPath, smsWebhookId)
 
@@ -94,7 +113,7 @@ func (s *smsWebhookService) Get(ctx context.Context, smsWebhookId string) (*Sing
 	}
 
 	root := new(SingleSmsWebhookRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsWebhook.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -109,7 +128,7 @@ func (s *smsWebhookService) Create(ctx context.Context, options *CreateSmsWebhoo
 	}
 
 	root := new(SingleSmsWebhookRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsWebhook.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -126,7 +145,7 @@ func (s *smsWebhookService) Update(ctx context.Context, options *UpdateSmsWebhoo
 	}
 
 	root := new(SingleSmsWebhookRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "SmsWebhook.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -142,5 +161,5 @@ func (s *smsWebhookService) Delete(ctx context.Context, smsWebhookId string) (*R
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "SmsWebhook.Delete", req, nil)
 }
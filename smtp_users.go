@@ -11,6 +11,7 @@ const smtpUsersBasePath = "/domains/%s/smtp-users"
 // SmtpUserService defines the interface for SMTP user operations
 type SmtpUserService interface {
 	List(ctx context.Context, domainID string, options *ListSmtpUserOptions) (*SmtpUserRoot, *Response, error)
+	ListAll(ctx context.Context, domainID string, options *ListSmtpUserOptions) *Iterator[SmtpUser]
 	Get(ctx context.Context, domainID string, smtpUserID string) (*SingleSmtpUserRoot, *Response, error)
 	Create(ctx context.Context, domainID string, options *CreateSmtpUserOptions) (*SingleSmtpUserRoot, *Response, error)
 	Update(ctx context.Context, domainID string, smtpUserID string, options *UpdateSmtpUserOptions) (*SingleSmtpUserRoot, *Response, error)
@@ -71,7 +72,7 @@ func (s *smtpUserService) List(ctx context.Context, domainID string, options *Li
 	}
 
 	smtpUsers := new(SmtpUserRoot)
-	res, err := s.client.do(ctx, req, smtpUsers)
+	res, err := s.client.do(ctx, "SmtpUser.List", req, smtpUsers)
 	if err != nil {
 		return nil, res, err
 	}
@@ -79,6 +80,24 @@ func (s *smtpUserService) List(ctx context.Context, domainID string, options *Li
 	return smtpUsers, res, nil
 }
 
+func (s *smtpUserService) ListAll(ctx context.Context, domainID string, options *ListSmtpUserOptions) *Iterator[SmtpUser] {
+	opts := ListSmtpUserOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SmtpUser, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, domainID, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 // Get retrieves a single SMTP user by ID
 func (s *smtpUserService) Get(ctx context.Context, domainID string, smtpUserID string) (*SingleSmtpUserRoot, *Response, error) {
 	path := fmt.Sprintf(smtpUsersBasePath+"/%s", domainID, smtpUserID)
@@ -89,7 +108,7 @@ func (s *smtpUserService) Get(ctx context.Context, domainID string, smtpUserID s
 	}
 
 	smtpUser := new(SingleSmtpUserRoot)
-	res, err := s.client.do(ctx, req, smtpUser)
+	res, err := s.client.do(ctx, "SmtpUser.Get", req, smtpUser)
 	if err != nil {
 		return nil, res, err
 	}
@@ -107,7 +126,7 @@ func (s *smtpUserService) Create(ctx context.Context, domainID string, options *
 	}
 
 	smtpUser := new(SingleSmtpUserRoot)
-	res, err := s.client.do(ctx, req, smtpUser)
+	res, err := s.client.do(ctx, "SmtpUser.Create", req, smtpUser)
 	if err != nil {
 		return nil, res, err
 	}
@@ -125,7 +144,7 @@ func (s *smtpUserService) Update(ctx context.Context, domainID string, smtpUserI
 	}
 
 	smtpUser := new(SingleSmtpUserRoot)
-	res, err := s.client.do(ctx, req, smtpUser)
+	res, err := s.client.do(ctx, "SmtpUser.Update", req, smtpUser)
 	if err != nil {
 		return nil, res, err
 	}
@@ -142,5 +161,5 @@ func (s *smtpUserService) Delete(ctx context.Context, domainID string, smtpUserI
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "SmtpUser.Delete", req, nil)
 }
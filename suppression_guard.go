@@ -0,0 +1,388 @@
+package mailersend
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SuppressionGuardMode - what the SuppressionGuard does with suppressed recipients
+type SuppressionGuardMode int
+
+const (
+	// SuppressionGuardStrip removes suppressed recipients and sends to the others.
+	SuppressionGuardStrip SuppressionGuardMode = iota
+	// SuppressionGuardReject fails the send with a *SuppressedRecipientsError.
+	SuppressionGuardReject
+)
+
+// SuppressedRecipient - a recipient removed or rejected by the SuppressionGuard
+type SuppressedRecipient struct {
+	// MessageIndex is the index of the message in a bulk email request, 0 for single sends
+	MessageIndex int
+	// Field is the recipient field of the message, "to", "cc" or "bcc"
+	Field string
+	Email string
+	// List is the suppression list that matched, like HardBounces
+	List SuppressionType
+	// Pattern is the blocklist pattern that matched, empty for the other lists
+	Pattern string
+}
+
+// SuppressedRecipientsError - returned by sends when the SuppressionGuard rejects suppressed
+// recipients, or when every "to" recipient of a message is suppressed
+type SuppressedRecipientsError struct {
+	Recipients []SuppressedRecipient
+}
+
+func (e *SuppressedRecipientsError) Error() string {
+	emails := make([]string, 0, len(e.Recipients))
+	for _, recipient := range e.Recipients {
+		emails = append(emails, fmt.Sprintf("%s (%s)", recipient.Email, recipient.List))
+	}
+
+	return fmt.Sprintf("mailersend: suppressed recipients: %s", strings.Join(emails, ", "))
+}
+
+// SuppressionGuardOptions - modifies the behavior of the SuppressionGuard
+type SuppressionGuardOptions struct {
+	// Mode is SuppressionGuardStrip by default.
+	Mode SuppressionGuardMode
+	// DomainID limits the synced suppressions to one domain, all domains are synced when empty.
+	DomainID string
+	// Lists are the suppression lists to sync, BlockList, HardBounces, SpamComplaints and
+	// Unsubscribes by default.
+	Lists []SuppressionType
+	// OnSuppressed is called with the recipients removed or rejected by every send.
+	OnSuppressed func(recipients []SuppressedRecipient)
+	// Logger reports sync errors hit by Run.
+	Logger Logger
+}
+
+// SuppressionGuard - checks the recipients of messages against a local copy of the suppression
+// lists before they are sent, so suppressed addresses do not use up quota.
+// Add it to a client with SetSuppressionGuard and keep it current with Sync or Run.
+type SuppressionGuard struct {
+	service SuppressionService
+	options SuppressionGuardOptions
+
+	mu       sync.RWMutex
+	index    *suppressionIndex
+	syncedAt time.Time
+}
+
+// suppressionIndex is one synced copy of the suppression lists, replaced as a whole.
+type suppressionIndex struct {
+	// emails holds the suppressions of every address, keyed by lower case address.
+	emails   map[string][]suppressionMatch
+	patterns []suppressionMatch
+}
+
+type suppressionMatch struct {
+	list    SuppressionType
+	domain  string
+	pattern string
+	re      *regexp.Regexp
+}
+
+// NewSuppressionGuard - creates a guard syncing its cache from service, options may be nil
+func NewSuppressionGuard(service SuppressionService, options *SuppressionGuardOptions) *SuppressionGuard {
+	g := &SuppressionGuard{
+		service: service,
+		index:   &suppressionIndex{emails: map[string][]suppressionMatch{}},
+	}
+	if options != nil {
+		g.options = *options
+	}
+	if len(g.options.Lists) == 0 {
+		g.options.Lists = suppressionTypes
+	}
+
+	return g
+}
+
+// SetSuppressionGuard - Check the recipients of EmailService.Send, SendWithResult and
+// BulkEmailService.Send with guard, nil disables the check
+func (ms *Mailersend) SetSuppressionGuard(guard *SuppressionGuard) {
+	ms.update(func(state *clientState) {
+		state.suppressionGuard = guard
+	})
+}
+
+// Sync - replaces the cache with the current suppression lists. The previous cache is kept when it fails.
+func (g *SuppressionGuard) Sync(ctx context.Context) error {
+	index := &suppressionIndex{emails: map[string][]suppressionMatch{}}
+	options := &SuppressionOptions{DomainID: g.options.DomainID, Limit: 100}
+
+	for _, list := range g.options.Lists {
+		it := g.service.ListAll(ctx, list, options)
+		for it.Next() {
+			entry := it.Item()
+			if list == BlockList {
+				index.addPattern(list, entry.Domain.Name, entry.Value)
+			} else {
+				index.addEmail(list, entry.Domain.Name, entry.Value)
+			}
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.index = index
+	g.syncedAt = time.Now()
+
+	return nil
+}
+
+// Run - syncs the cache every interval until ctx is done, then returns ctx.Err()
+func (g *SuppressionGuard) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := g.Sync(ctx); err != nil && ctx.Err() == nil && g.options.Logger != nil {
+			g.options.Logger.Printf("mailersend: syncing suppressions: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncedAt - returns when the cache was last synced, zero before the first Sync
+func (g *SuppressionGuard) SyncedAt() time.Time {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.syncedAt
+}
+
+// Check - reports whether email is suppressed for messages sent from the from address,
+// and returns the list and blocklist pattern that matched
+func (g *SuppressionGuard) Check(from string, email string) (list SuppressionType, pattern string, suppressed bool) {
+	g.mu.RLock()
+	index := g.index
+	g.mu.RUnlock()
+
+	match, ok := index.match(emailDomain(from), email)
+	if !ok {
+		return "", "", false
+	}
+
+	return match.list, match.pattern, true
+}
+
+// Filter - returns copies of messages without their suppressed recipients and reports what was removed.
+// In reject mode, or when every "to" recipient of a message is suppressed, it returns a
+// *SuppressedRecipientsError instead. The messages passed in are never modified.
+func (g *SuppressionGuard) Filter(messages []*Message) ([]*Message, []SuppressedRecipient, error) {
+	filtered, suppressed, _, err := g.filter(messages, false)
+	return filtered, suppressed, err
+}
+
+// filter is Filter for single sends, and for bulk sends when drop is true. Then, in strip mode,
+// messages whose "to" recipients are all suppressed are left out and their indexes returned in
+// dropped, an error is only returned when no message is left.
+func (g *SuppressionGuard) filter(messages []*Message, drop bool) (filtered []*Message, suppressed []SuppressedRecipient, dropped []int, err error) {
+	g.mu.RLock()
+	index := g.index
+	g.mu.RUnlock()
+
+	filtered = make([]*Message, len(messages))
+	var emptied []SuppressedRecipient
+
+	for i, message := range messages {
+		if message == nil {
+			continue
+		}
+
+		domain := emailDomain(message.From.Email)
+		removed := map[string]bool{}
+		strip := func(field string, recipients []Recipient) []Recipient {
+			var kept []Recipient
+			for _, recipient := range recipients {
+				match, ok := index.match(domain, recipient.Email)
+				if !ok {
+					kept = append(kept, recipient)
+					continue
+				}
+
+				removed[strings.ToLower(recipient.Email)] = true
+				suppressed = append(suppressed, SuppressedRecipient{
+					MessageIndex: i,
+					Field:        field,
+					Email:        recipient.Email,
+					List:         match.list,
+					Pattern:      match.pattern,
+				})
+			}
+			return kept
+		}
+
+		copied := *message
+		before := len(suppressed)
+		copied.Recipients = strip("to", message.Recipients)
+		if len(copied.Recipients) == 0 && len(message.Recipients) > 0 {
+			emptied = append(emptied, suppressed[before:]...)
+			dropped = append(dropped, i)
+		}
+		copied.CC = strip("cc", message.CC)
+		copied.Bcc = strip("bcc", message.Bcc)
+
+		if len(removed) > 0 {
+			copied.Personalization = nil
+			for _, personalization := range message.Personalization {
+				if !removed[strings.ToLower(personalization.Email)] {
+					copied.Personalization = append(copied.Personalization, personalization)
+				}
+			}
+			filtered[i] = &copied
+		} else {
+			filtered[i] = message
+		}
+	}
+
+	if len(suppressed) > 0 && g.options.OnSuppressed != nil {
+		g.options.OnSuppressed(suppressed)
+	}
+
+	if len(suppressed) > 0 && g.options.Mode == SuppressionGuardReject {
+		return nil, suppressed, nil, &SuppressedRecipientsError{Recipients: suppressed}
+	}
+	if len(dropped) == 0 {
+		return filtered, suppressed, nil, nil
+	}
+	if !drop || len(dropped) == len(messages) {
+		return nil, suppressed, nil, &SuppressedRecipientsError{Recipients: emptied}
+	}
+
+	kept := make([]*Message, 0, len(messages)-len(dropped))
+	next := 0
+	for i, message := range filtered {
+		if next < len(dropped) && dropped[next] == i {
+			next++
+			continue
+		}
+		kept = append(kept, message)
+	}
+
+	return kept, suppressed, dropped, nil
+}
+
+// guard filters messages with the suppression guard of the client, if any.
+func (state *clientState) guard(messages ...*Message) ([]*Message, []SuppressedRecipient, error) {
+	if state.suppressionGuard == nil {
+		return messages, nil, nil
+	}
+
+	return state.suppressionGuard.Filter(messages)
+}
+
+// guardBulk filters the messages of a bulk email with the suppression guard of the client, if any,
+// leaving out the messages without "to" recipients in strip mode.
+func (state *clientState) guardBulk(messages []*Message) ([]*Message, []SuppressedRecipient, []int, error) {
+	if state.suppressionGuard == nil {
+		return messages, nil, nil, nil
+	}
+
+	return state.suppressionGuard.filter(messages, true)
+}
+
+func (i *suppressionIndex) addEmail(list SuppressionType, domain string, email string) {
+	if email == "" {
+		return
+	}
+
+	email = strings.ToLower(email)
+	i.emails[email] = append(i.emails[email], suppressionMatch{
+		list:   list,
+		domain: strings.ToLower(domain),
+	})
+}
+
+// addPattern adds a blocklist entry, either an address or a pattern where "*" or ".*" match anything.
+func (i *suppressionIndex) addPattern(list SuppressionType, domain string, pattern string) {
+	if pattern == "" {
+		return
+	}
+
+	domain = strings.ToLower(domain)
+	re := blocklistRegexp(pattern)
+	if re == nil {
+		email := strings.ToLower(pattern)
+		i.emails[email] = append(i.emails[email], suppressionMatch{list: list, domain: domain, pattern: pattern})
+		return
+	}
+
+	i.patterns = append(i.patterns, suppressionMatch{
+		list:    list,
+		domain:  domain,
+		pattern: pattern,
+		re:      re,
+	})
+}
+
+// blocklistRegexp returns the expression matching a blocklist pattern, or nil when it is an address.
+func blocklistRegexp(pattern string) *regexp.Regexp {
+	glob := strings.ReplaceAll(pattern, ".*", "*")
+	if !strings.Contains(glob, "*") {
+		return nil
+	}
+
+	parts := strings.Split(glob, "*")
+	for n, part := range parts {
+		parts[n] = regexp.QuoteMeta(part)
+	}
+
+	return regexp.MustCompile("(?i)^" + strings.Join(parts, ".*") + "$")
+}
+
+// MatchBlocklistPattern - reports whether email matches a blocklist entry, either an address or
+// a pattern with * or .* wildcards, ignoring case
+func MatchBlocklistPattern(pattern string, email string) bool {
+	if re := blocklistRegexp(pattern); re != nil {
+		return re.MatchString(email)
+	}
+	return strings.EqualFold(pattern, email)
+}
+
+// match returns the suppression of email for messages sent from domain. Suppressions without
+// a domain apply to every domain.
+func (i *suppressionIndex) match(domain string, email string) (suppressionMatch, bool) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	applies := func(match suppressionMatch) bool {
+		return match.domain == "" || domain == "" || match.domain == domain
+	}
+
+	for _, match := range i.emails[email] {
+		if applies(match) {
+			return match, true
+		}
+	}
+	for _, match := range i.patterns {
+		if applies(match) && match.re.MatchString(email) {
+			return match, true
+		}
+	}
+
+	return suppressionMatch{}, false
+}
+
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+
+	return strings.ToLower(email[at+1:])
+}
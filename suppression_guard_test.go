@@ -0,0 +1,132 @@
+package mailersend_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/mailersend/mailersend-go/mailersendtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func guardedMessage(recipients ...string) *mailersend.Message {
+	message := &mailersend.Message{}
+	message.SetFrom(mailersend.From{Email: "sender@example.com"})
+	for _, email := range recipients {
+		message.Recipients = append(message.Recipients, mailersend.Recipient{Email: email})
+	}
+	message.SetCc([]mailersend.Recipient{{Email: "Bounced@client.com"}})
+	message.SetSubject("Subject")
+	message.SetText("Text")
+
+	return message
+}
+
+func TestSuppressionGuardStripsRecipients(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	domain := srv.AddDomain("example.com")
+	other := srv.AddDomain("other.com")
+	srv.AddSuppression(mailersend.HardBounces, domain.ID, "bounced@client.com")
+	srv.AddSuppression(mailersend.Unsubscribes, other.ID, "other@client.com")
+	srv.AddSuppression(mailersend.BlockList, domain.ID, "*@blocked.com")
+
+	var reported []mailersend.SuppressedRecipient
+	ms := srv.NewMailersend()
+	guard := mailersend.NewSuppressionGuard(ms.Suppression, &mailersend.SuppressionGuardOptions{
+		OnSuppressed: func(recipients []mailersend.SuppressedRecipient) { reported = append(reported, recipients...) },
+	})
+	assert.NoError(t, guard.Sync(context.TODO()))
+	assert.False(t, guard.SyncedAt().IsZero())
+	ms.SetSuppressionGuard(guard)
+
+	message := guardedMessage("user@client.com", "someone@blocked.com", "other@client.com")
+	result, err := ms.Email.SendWithResult(context.TODO(), message)
+	assert.NoError(t, err)
+
+	expected := []mailersend.SuppressedRecipient{
+		{Field: "to", Email: "someone@blocked.com", List: mailersend.BlockList, Pattern: "*@blocked.com"},
+		{Field: "cc", Email: "Bounced@client.com", List: mailersend.HardBounces},
+	}
+	assert.Equal(t, expected, result.Suppressed)
+	assert.Equal(t, expected, reported)
+	assert.Empty(t, result.Warnings)
+
+	sent := srv.SentEmails()[0].Message
+	assert.Equal(t, []mailersend.Recipient{{Email: "user@client.com"}, {Email: "other@client.com"}}, sent.Recipients)
+	assert.Empty(t, sent.CC)
+	assert.Len(t, message.Recipients, 3, "the message passed in is not modified")
+
+	_, err = ms.Email.Send(context.TODO(), guardedMessage("someone@blocked.com"))
+	var suppressedErr *mailersend.SuppressedRecipientsError
+	assert.True(t, errors.As(err, &suppressedErr))
+	assert.Equal(t, "someone@blocked.com", suppressedErr.Recipients[0].Email)
+}
+
+func TestSuppressionGuardRejectsBulkEmail(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	domain := srv.AddDomain("example.com")
+	srv.AddSuppression(mailersend.SpamComplaints, domain.ID, "complained@client.com")
+
+	ms := srv.NewMailersend()
+	guard := mailersend.NewSuppressionGuard(ms.Suppression, &mailersend.SuppressionGuardOptions{Mode: mailersend.SuppressionGuardReject})
+	assert.NoError(t, guard.Sync(context.TODO()))
+	ms.SetSuppressionGuard(guard)
+
+	list, _, suppressed := guard.Check("sender@example.com", "COMPLAINED@client.com")
+	assert.True(t, suppressed)
+	assert.Equal(t, mailersend.SpamComplaints, list)
+
+	_, _, err := ms.BulkEmail.Send(context.TODO(), []*mailersend.Message{
+		guardedMessage("user@client.com"),
+		guardedMessage("complained@client.com"),
+	})
+
+	var suppressedErr *mailersend.SuppressedRecipientsError
+	assert.True(t, errors.As(err, &suppressedErr))
+	assert.Equal(t, []mailersend.SuppressedRecipient{
+		{MessageIndex: 1, Field: "to", Email: "complained@client.com", List: mailersend.SpamComplaints},
+	}, suppressedErr.Recipients)
+	assert.Empty(t, srv.SentEmails())
+}
+
+func TestSuppressionGuardDropsEmptiedBulkMessages(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	domain := srv.AddDomain("example.com")
+	srv.AddSuppression(mailersend.HardBounces, domain.ID, "bounced@client.com")
+
+	ms := srv.NewMailersend()
+	guard := mailersend.NewSuppressionGuard(ms.Suppression, nil)
+	assert.NoError(t, guard.Sync(context.TODO()))
+	ms.SetSuppressionGuard(guard)
+
+	messages := []*mailersend.Message{
+		guardedMessage("first@client.com"),
+		guardedMessage("bounced@client.com"),
+		guardedMessage("third@client.com"),
+	}
+
+	res, _, err := ms.BulkEmail.Send(context.TODO(), messages)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, res.Dropped)
+	assert.Contains(t, res.Suppressed, mailersend.SuppressedRecipient{
+		MessageIndex: 1, Field: "to", Email: "bounced@client.com", List: mailersend.HardBounces,
+	})
+
+	result, err := ms.BulkEmail.SendChunked(context.TODO(), messages, &mailersend.SendChunkedOptions{ChunkSize: 2})
+	assert.NoError(t, err)
+	assert.Len(t, result.BulkEmailIDs, 2)
+	assert.Equal(t, []int{1}, result.Chunks[0].Dropped)
+	assert.Equal(t, 2, result.Chunks[1].Suppressed[0].MessageIndex)
+
+	// Single sends, and bulk sends with nothing left to send, still fail.
+	_, _, err = ms.BulkEmail.Send(context.TODO(), messages[1:2])
+	var suppressedErr *mailersend.SuppressedRecipientsError
+	assert.True(t, errors.As(err, &suppressedErr))
+}
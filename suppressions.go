@@ -3,36 +3,135 @@ package mailersend
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
-const (
-	suppressionBasePath = "/suppressions"
+const suppressionBasePath = "/suppressions"
+
+// SuppressionType - a suppression list
+type SuppressionType string
 
-	BlockList      string = "blocklist"
-	HardBounces    string = "hard-bounces"
-	SpamComplaints string = "spam-complaints"
-	Unsubscribes   string = "unsubscribes"
+const (
+	BlockList      SuppressionType = "blocklist"
+	HardBounces    SuppressionType = "hard-bounces"
+	SpamComplaints SuppressionType = "spam-complaints"
+	Unsubscribes   SuppressionType = "unsubscribes"
 )
 
+// suppressionTypes are all suppression lists, in the order they are read when no lists are given.
+var suppressionTypes = []SuppressionType{BlockList, HardBounces, SpamComplaints, Unsubscribes}
+
+func (t SuppressionType) valid() bool {
+	for _, suppressionType := range suppressionTypes {
+		if t == suppressionType {
+			return true
+		}
+	}
+
+	return false
+}
+
 type SuppressionService interface {
+	List(ctx context.Context, suppressionType SuppressionType, options *SuppressionOptions) (*SuppressionEntriesRoot, *Response, error)
+	ListAll(ctx context.Context, suppressionType SuppressionType, options *SuppressionOptions) *Iterator[SuppressionEntry]
+	Create(ctx context.Context, suppressionType SuppressionType, options *CreateSuppressionOptions) (*SuppressionEntriesRoot, *Response, error)
 	ListBlockList(ctx context.Context, options *SuppressionOptions) (*SuppressionBlockListRoot, *Response, error)
+	ListAllBlockList(ctx context.Context, options *SuppressionOptions) *Iterator[SuppressionBlockListData]
 	ListHardBounces(ctx context.Context, options *SuppressionOptions) (*SuppressionHardBouncesRoot, *Response, error)
+	ListAllHardBounces(ctx context.Context, options *SuppressionOptions) *Iterator[SuppressionHardBouncesData]
 	ListSpamComplaints(ctx context.Context, options *SuppressionOptions) (*SuppressionSpamComplaintsRoot, *Response, error)
+	ListAllSpamComplaints(ctx context.Context, options *SuppressionOptions) *Iterator[SuppressionSpamComplaintsData]
 	ListUnsubscribes(ctx context.Context, options *SuppressionOptions) (*SuppressionUnsubscribesRoot, *Response, error)
+	ListAllUnsubscribes(ctx context.Context, options *SuppressionOptions) *Iterator[SuppressionUnsubscribesData]
 	CreateBlock(ctx context.Context, options *CreateSuppressionBlockOptions) (*SuppressionBlockResponse, *Response, error)
 	CreateHardBounce(ctx context.Context, options *CreateSuppressionOptions) (*SuppressionHardBouncesRoot, *Response, error)
 	CreateSpamComplaint(ctx context.Context, options *CreateSuppressionOptions) (*SuppressionSpamComplaintsRoot, *Response, error)
 	CreateUnsubscribe(ctx context.Context, options *CreateSuppressionOptions) (*SuppressionUnsubscribesRoot, *Response, error)
-	Delete(ctx context.Context, options *DeleteSuppressionOptions, suppressionType string) (*Response, error)
-	DeleteAll(ctx context.Context, domainID string, suppressionType string) (*Response, error)
+	Delete(ctx context.Context, options *DeleteSuppressionOptions, suppressionType SuppressionType) (*Response, error)
+	DeleteAll(ctx context.Context, domainID string, suppressionType SuppressionType) (*Response, error)
+	DeleteByEmail(ctx context.Context, domainID string, suppressionType SuppressionType, emails []string) ([]SuppressionEntry, error)
+	Find(ctx context.Context, email string) ([]SuppressionEntry, error)
+	Export(ctx context.Context, w io.Writer, options *SuppressionExportOptions) error
+	Import(ctx context.Context, r io.Reader, options *SuppressionImportOptions) (*SuppressionImportResult, error)
 }
 
 type suppressionService struct {
 	*service
 }
 
+// SuppressionEntriesRoot - entries of any suppression list
+type SuppressionEntriesRoot struct {
+	Data  []SuppressionEntry `json:"data"`
+	Links `json:"links"`
+	Meta  `json:"meta"`
+}
+
+// SuppressionEntry - an entry of any suppression list
+type SuppressionEntry struct {
+	ID   string          `json:"id"`
+	Type SuppressionType `json:"type"`
+	// Value is the email address, or the address or pattern of a blocklist entry.
+	Value          string `json:"value"`
+	Reason         string `json:"reason,omitempty"`
+	ReadableReason string `json:"readable_reason,omitempty"`
+	Domain         Domain `json:"domain"`
+	// Recipient is nil for blocklist entries.
+	Recipient *SuppressionRecipient `json:"recipient,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+// suppressionEntriesData holds the entries of any list as the API returns them.
+type suppressionEntriesData struct {
+	Data  []suppressionEntryData `json:"data"`
+	Links `json:"links"`
+	Meta  `json:"meta"`
+}
+
+type suppressionEntryData struct {
+	ID             string                `json:"id"`
+	Pattern        string                `json:"pattern"`
+	Reason         string                `json:"reason"`
+	ReadableReason string                `json:"readable_reason"`
+	Domain         Domain                `json:"domain"`
+	Recipient      *SuppressionRecipient `json:"recipient"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+func (d *suppressionEntriesData) root(suppressionType SuppressionType, domainID string) *SuppressionEntriesRoot {
+	root := &SuppressionEntriesRoot{
+		Data:  make([]SuppressionEntry, 0, len(d.Data)),
+		Links: d.Links,
+		Meta:  d.Meta,
+	}
+
+	for _, data := range d.Data {
+		entry := SuppressionEntry{
+			ID:             data.ID,
+			Type:           suppressionType,
+			Value:          data.Pattern,
+			Reason:         data.Reason,
+			ReadableReason: data.ReadableReason,
+			Domain:         data.Domain,
+			CreatedAt:      data.CreatedAt,
+		}
+		if data.Recipient != nil {
+			entry.Value = data.Recipient.Email
+			entry.Domain = data.Recipient.Domain
+			entry.Recipient = data.Recipient
+		}
+		// Created blocklist entries come back without their domain.
+		if entry.Domain.ID == "" {
+			entry.Domain.ID = domainID
+		}
+		root.Data = append(root.Data, entry)
+	}
+
+	return root
+}
+
 // SuppressionBlockListRoot - recipients response
 type SuppressionBlockListRoot struct {
 	Data  []SuppressionBlockListData `json:"data"`
@@ -121,6 +220,8 @@ type CreateSuppressionBlockOptions struct {
 type CreateSuppressionOptions struct {
 	DomainID   string   `json:"domain_id"`
 	Recipients []string `json:"recipients"`
+	// Patterns are only accepted by the blocklist.
+	Patterns []string `json:"patterns,omitempty"`
 }
 
 // SuppressionOptions - modifies the behavior of SuppressionService.List methods
@@ -140,35 +241,88 @@ type DeleteAll struct {
 	All      bool   `json:"all"`
 }
 
-func (s *suppressionService) ListBlockList(ctx context.Context, options *SuppressionOptions) (*SuppressionBlockListRoot, *Response, error) {
-	path := fmt.Sprintf("%s/%s", suppressionBasePath, BlockList)
+// List - returns a page of any suppression list
+func (s *suppressionService) List(ctx context.Context, suppressionType SuppressionType, options *SuppressionOptions) (*SuppressionEntriesRoot, *Response, error) {
+	if !suppressionType.valid() {
+		return nil, nil, fmt.Errorf("mailersend: unknown suppression list %q", suppressionType)
+	}
 
-	req, err := s.client.newRequest(http.MethodGet, path, options)
+	data := new(suppressionEntriesData)
+
+	res, err := s.list(ctx, "Suppression.List", suppressionType, options, data)
 	if err != nil {
-		return nil, nil, err
+		return nil, res, err
 	}
 
-	root := new(SuppressionBlockListRoot)
+	return data.root(suppressionType, ""), res, nil
+}
+
+// ListAll - returns an iterator over every entry of any suppression list
+func (s *suppressionService) ListAll(ctx context.Context, suppressionType SuppressionType, options *SuppressionOptions) *Iterator[SuppressionEntry] {
+	opts := SuppressionOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SuppressionEntry, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, suppressionType, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
 
-	res, err := s.client.do(ctx, req, root)
+// Create - adds recipients, or blocklist patterns, to any suppression list
+func (s *suppressionService) Create(ctx context.Context, suppressionType SuppressionType, options *CreateSuppressionOptions) (*SuppressionEntriesRoot, *Response, error) {
+	if !suppressionType.valid() {
+		return nil, nil, fmt.Errorf("mailersend: unknown suppression list %q", suppressionType)
+	}
+
+	data := new(suppressionEntriesData)
+
+	res, err := s.create(ctx, "Suppression.Create", suppressionType, options, data)
 	if err != nil {
 		return nil, res, err
 	}
 
-	return root, res, nil
+	domainID := ""
+	if options != nil {
+		domainID = options.DomainID
+	}
+
+	return data.root(suppressionType, domainID), res, nil
 }
 
-func (s *suppressionService) ListHardBounces(ctx context.Context, options *SuppressionOptions) (*SuppressionHardBouncesRoot, *Response, error) {
-	path := fmt.Sprintf("%s/%s", suppressionBasePath, HardBounces)
+func (s *suppressionService) list(ctx context.Context, operation string, suppressionType SuppressionType, options *SuppressionOptions, root interface{}) (*Response, error) {
+	path := fmt.Sprintf("%s/%s", suppressionBasePath, suppressionType)
 
 	req, err := s.client.newRequest(http.MethodGet, path, options)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	root := new(SuppressionHardBouncesRoot)
+	return s.client.do(ctx, operation, req, root)
+}
+
+func (s *suppressionService) create(ctx context.Context, operation string, suppressionType SuppressionType, options interface{}, root interface{}) (*Response, error) {
+	path := fmt.Sprintf("%s/%s", suppressionBasePath, suppressionType)
 
-	res, err := s.client.do(ctx, req, root)
+	req, err := s.client.newRequest(http.MethodPost, path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.do(ctx, operation, req, root)
+}
+
+func (s *suppressionService) ListBlockList(ctx context.Context, options *SuppressionOptions) (*SuppressionBlockListRoot, *Response, error) {
+	root := new(SuppressionBlockListRoot)
+
+	res, err := s.list(ctx, "Suppression.ListBlockList", BlockList, options, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -176,17 +330,57 @@ func (s *suppressionService) ListHardBounces(ctx context.Context, options *Suppr
 	return root, res, nil
 }
 
-func (s *suppressionService) ListSpamComplaints(ctx context.Context, options *SuppressionOptions) (*SuppressionSpamComplaintsRoot, *Response, error) {
-	path := fmt.Sprintf("%s/%s", suppressionBasePath, SpamComplaints)
+func (s *suppressionService) ListAllBlockList(ctx context.Context, options *SuppressionOptions) *Iterator[SuppressionBlockListData] {
+	opts := SuppressionOptions{}
+	if options != nil {
+		opts = *options
+	}
 
-	req, err := s.client.newRequest(http.MethodGet, path, options)
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SuppressionBlockListData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.ListBlockList(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
+func (s *suppressionService) ListHardBounces(ctx context.Context, options *SuppressionOptions) (*SuppressionHardBouncesRoot, *Response, error) {
+	root := new(SuppressionHardBouncesRoot)
+
+	res, err := s.list(ctx, "Suppression.ListHardBounces", HardBounces, options, root)
 	if err != nil {
-		return nil, nil, err
+		return nil, res, err
 	}
 
+	return root, res, nil
+}
+
+func (s *suppressionService) ListAllHardBounces(ctx context.Context, options *SuppressionOptions) *Iterator[SuppressionHardBouncesData] {
+	opts := SuppressionOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SuppressionHardBouncesData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.ListHardBounces(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
+func (s *suppressionService) ListSpamComplaints(ctx context.Context, options *SuppressionOptions) (*SuppressionSpamComplaintsRoot, *Response, error) {
 	root := new(SuppressionSpamComplaintsRoot)
 
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.list(ctx, "Suppression.ListSpamComplaints", SpamComplaints, options, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -194,17 +388,28 @@ func (s *suppressionService) ListSpamComplaints(ctx context.Context, options *Su
 	return root, res, nil
 }
 
-func (s *suppressionService) ListUnsubscribes(ctx context.Context, options *SuppressionOptions) (*SuppressionUnsubscribesRoot, *Response, error) {
-	path := fmt.Sprintf("%s/%s", suppressionBasePath, Unsubscribes)
-
-	req, err := s.client.newRequest(http.MethodGet, path, options)
-	if err != nil {
-		return nil, nil, err
+func (s *suppressionService) ListAllSpamComplaints(ctx context.Context, options *SuppressionOptions) *Iterator[SuppressionSpamComplaintsData] {
+	opts := SuppressionOptions{}
+	if options != nil {
+		opts = *options
 	}
 
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SuppressionSpamComplaintsData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.ListSpamComplaints(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
+func (s *suppressionService) ListUnsubscribes(ctx context.Context, options *SuppressionOptions) (*SuppressionUnsubscribesRoot, *Response, error) {
 	root := new(SuppressionUnsubscribesRoot)
 
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.list(ctx, "Suppression.ListUnsubscribes", Unsubscribes, options, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -212,15 +417,28 @@ func (s *suppressionService) ListUnsubscribes(ctx context.Context, options *Supp
 	return root, res, nil
 }
 
-func (s *suppressionService) CreateBlock(ctx context.Context, options *CreateSuppressionBlockOptions) (*SuppressionBlockResponse, *Response, error) {
-	path := fmt.Sprintf("%s/%s", suppressionBasePath, BlockList)
-	req, err := s.client.newRequest(http.MethodPost, path, options)
-	if err != nil {
-		return nil, nil, err
+func (s *suppressionService) ListAllUnsubscribes(ctx context.Context, options *SuppressionOptions) *Iterator[SuppressionUnsubscribesData] {
+	opts := SuppressionOptions{}
+	if options != nil {
+		opts = *options
 	}
 
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]SuppressionUnsubscribesData, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.ListUnsubscribes(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
+func (s *suppressionService) CreateBlock(ctx context.Context, options *CreateSuppressionBlockOptions) (*SuppressionBlockResponse, *Response, error) {
 	root := new(SuppressionBlockResponse)
-	res, err := s.client.do(ctx, req, root)
+
+	res, err := s.create(ctx, "Suppression.CreateBlock", BlockList, options, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -229,14 +447,9 @@ func (s *suppressionService) CreateBlock(ctx context.Context, options *CreateSup
 }
 
 func (s *suppressionService) CreateHardBounce(ctx context.Context, options *CreateSuppressionOptions) (*SuppressionHardBouncesRoot, *Response, error) {
-	path := fmt.Sprintf("%s/%s", suppressionBasePath, HardBounces)
-	req, err := s.client.newRequest(http.MethodPost, path, options)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	root := new(SuppressionHardBouncesRoot)
-	res, err := s.client.do(ctx, req, root)
+
+	res, err := s.create(ctx, "Suppression.CreateHardBounce", HardBounces, options, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -245,14 +458,9 @@ func (s *suppressionService) CreateHardBounce(ctx context.Context, options *Crea
 }
 
 func (s *suppressionService) CreateSpamComplaint(ctx context.Context, options *CreateSuppressionOptions) (*SuppressionSpamComplaintsRoot, *Response, error) {
-	path := fmt.Sprintf("%s/%s", suppressionBasePath, SpamComplaints)
-	req, err := s.client.newRequest(http.MethodPost, path, options)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	root := new(SuppressionSpamComplaintsRoot)
-	res, err := s.client.do(ctx, req, root)
+
+	res, err := s.create(ctx, "Suppression.CreateSpamComplaint", SpamComplaints, options, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -261,14 +469,9 @@ func (s *suppressionService) CreateSpamComplaint(ctx context.Context, options *C
 }
 
 func (s *suppressionService) CreateUnsubscribe(ctx context.Context, options *CreateSuppressionOptions) (*SuppressionUnsubscribesRoot, *Response, error) {
-	path := fmt.Sprintf("%s/%s", suppressionBasePath, Unsubscribes)
-	req, err := s.client.newRequest(http.MethodPost, path, options)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	root := new(SuppressionUnsubscribesRoot)
-	res, err := s.client.do(ctx, req, root)
+
+	res, err := s.create(ctx, "Suppression.CreateUnsubscribe", Unsubscribes, options, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -276,7 +479,7 @@ func (s *suppressionService) CreateUnsubscribe(ctx context.Context, options *Cre
 	return root, res, nil
 }
 
-func (s *suppressionService) Delete(ctx context.Context, options *DeleteSuppressionOptions, suppressionType string) (*Response, error) {
+func (s *suppressionService) Delete(ctx context.Context, options *DeleteSuppressionOptions, suppressionType SuppressionType) (*Response, error) {
 	path := fmt.Sprintf("%s/%s", suppressionBasePath, suppressionType)
 
 	req, err := s.client.newRequest(http.MethodDelete, path, options)
@@ -284,11 +487,11 @@ func (s *suppressionService) Delete(ctx context.Context, options *DeleteSuppress
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Suppression.Delete", req, nil)
 
 }
 
-func (s *suppressionService) DeleteAll(ctx context.Context, domainID string, suppressionType string) (*Response, error) {
+func (s *suppressionService) DeleteAll(ctx context.Context, domainID string, suppressionType SuppressionType) (*Response, error) {
 	path := fmt.Sprintf("%s/%s", suppressionBasePath, suppressionType)
 
 	options := DeleteAll{All: true, DomainID: domainID}
@@ -298,5 +501,81 @@ func (s *suppressionService) DeleteAll(ctx context.Context, domainID string, sup
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Suppression.DeleteAll", req, nil)
+}
+
+// Find - returns the entries of every suppression list and domain that match email,
+// including blocklist patterns. All lists are fetched, so use it for single lookups.
+func (s *suppressionService) Find(ctx context.Context, email string) ([]SuppressionEntry, error) {
+	email = strings.TrimSpace(email)
+
+	var found []SuppressionEntry
+	err := s.eachSuppression(ctx, "", nil, func(entry SuppressionEntry) error {
+		matches := strings.EqualFold(entry.Value, email)
+		if entry.Type == BlockList {
+			matches = MatchBlocklistPattern(entry.Value, email)
+		}
+		if matches {
+			found = append(found, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// DeleteByEmail - removes emails from the suppression list of the domain and returns the deleted entries.
+// An empty domainID searches every domain and an empty suppressionType every list.
+// Blocklist patterns are only removed when given exactly.
+func (s *suppressionService) DeleteByEmail(ctx context.Context, domainID string, suppressionType SuppressionType, emails []string) ([]SuppressionEntry, error) {
+	wanted := map[string]bool{}
+	for _, email := range emails {
+		wanted[strings.ToLower(strings.TrimSpace(email))] = true
+	}
+
+	var lists []SuppressionType
+	if suppressionType != "" {
+		lists = []SuppressionType{suppressionType}
+	}
+
+	type group struct {
+		list     SuppressionType
+		domainID string
+	}
+
+	var order []group
+	matched := map[group][]SuppressionEntry{}
+	err := s.eachSuppression(ctx, domainID, lists, func(entry SuppressionEntry) error {
+		if !wanted[strings.ToLower(entry.Value)] {
+			return nil
+		}
+
+		g := group{entry.Type, entry.Domain.ID}
+		if _, ok := matched[g]; !ok {
+			order = append(order, g)
+		}
+		matched[g] = append(matched[g], entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []SuppressionEntry
+	for _, g := range order {
+		options := &DeleteSuppressionOptions{DomainID: g.domainID}
+		for _, entry := range matched[g] {
+			options.Ids = append(options.Ids, entry.ID)
+		}
+
+		if _, err := s.Delete(ctx, options, g.list); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, matched[g]...)
+	}
+
+	return deleted, nil
 }
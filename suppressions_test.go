@@ -0,0 +1,110 @@
+package mailersend_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/mailersend/mailersend-go/mailersendtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAndDeleteSuppressionsByEmail(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	first := srv.AddDomain("example.com")
+	second := srv.AddDomain("example.org")
+	srv.AddSuppression(mailersend.Unsubscribes, first.ID, "jane@client.com", "john@client.com")
+	srv.AddSuppression(mailersend.Unsubscribes, second.ID, "Jane@client.com")
+	srv.AddSuppression(mailersend.HardBounces, first.ID, "jane@client.com")
+	srv.AddSuppression(mailersend.BlockList, second.ID, "*@client.com")
+
+	ms := srv.NewMailersend()
+	ctx := context.TODO()
+
+	found, err := ms.Suppression.Find(ctx, "jane@client.com")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		string(mailersend.BlockList) + " " + second.ID,
+		string(mailersend.HardBounces) + " " + first.ID,
+		string(mailersend.Unsubscribes) + " " + first.ID,
+		string(mailersend.Unsubscribes) + " " + second.ID,
+	}, suppressionLocations(found))
+
+	deleted, err := ms.Suppression.DeleteByEmail(ctx, "", mailersend.Unsubscribes, []string{"JANE@client.com"})
+	assert.NoError(t, err)
+	assert.Len(t, deleted, 2)
+
+	deleted, err = ms.Suppression.DeleteByEmail(ctx, first.ID, "", []string{"jane@client.com", "missing@client.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{string(mailersend.HardBounces) + " " + first.ID}, suppressionLocations(deleted))
+
+	found, err = ms.Suppression.Find(ctx, "jane@client.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{string(mailersend.BlockList) + " " + second.ID}, suppressionLocations(found))
+
+	found, err = ms.Suppression.Find(ctx, "john@client.com")
+	assert.NoError(t, err)
+	assert.Len(t, found, 2)
+}
+
+func suppressionLocations(entries []mailersend.SuppressionEntry) []string {
+	var locations []string
+	for _, entry := range entries {
+		locations = append(locations, string(entry.Type)+" "+entry.Domain.ID)
+	}
+	return locations
+}
+
+func TestListAndCreateSuppressionsByType(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	domain := srv.AddDomain("example.com")
+	ms := srv.NewMailersend()
+	ctx := context.TODO()
+
+	created, _, err := ms.Suppression.Create(ctx, mailersend.BlockList, &mailersend.CreateSuppressionOptions{
+		DomainID:   domain.ID,
+		Recipients: []string{"jane@client.com"},
+		Patterns:   []string{"*@spam.com"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, created.Data, 2) {
+		assert.Equal(t, mailersend.BlockList, created.Data[1].Type)
+		assert.Equal(t, "*@spam.com", created.Data[1].Value)
+		assert.Equal(t, domain.ID, created.Data[1].Domain.ID)
+	}
+
+	created, _, err = ms.Suppression.Create(ctx, mailersend.Unsubscribes, &mailersend.CreateSuppressionOptions{
+		DomainID:   domain.ID,
+		Recipients: []string{"john@client.com"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, created.Data, 1) {
+		assert.Equal(t, "john@client.com", created.Data[0].Value)
+		assert.Equal(t, "example.com", created.Data[0].Domain.Name)
+		assert.NotNil(t, created.Data[0].Recipient)
+	}
+
+	for _, suppressionType := range []mailersend.SuppressionType{mailersend.BlockList, mailersend.Unsubscribes} {
+		root, _, err := ms.Suppression.List(ctx, suppressionType, &mailersend.SuppressionOptions{DomainID: domain.ID})
+		assert.NoError(t, err)
+		for _, entry := range root.Data {
+			assert.Equal(t, suppressionType, entry.Type)
+			assert.Equal(t, domain.ID, entry.Domain.ID)
+		}
+	}
+
+	entries, err := ms.Suppression.ListAll(ctx, mailersend.Unsubscribes, nil).All()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	_, _, err = ms.Suppression.List(ctx, mailersend.SuppressionType("bounces"), nil)
+	assert.EqualError(t, err, `mailersend: unknown suppression list "bounces"`)
+
+	unsubscribes, _, err := ms.Suppression.ListUnsubscribes(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, entries[0].ID, unsubscribes.Data[0].ID)
+}
@@ -0,0 +1,393 @@
+package mailersend
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// SuppressionFormat - file format of suppression exports and imports
+type SuppressionFormat string
+
+const (
+	// SuppressionFormatCSV has a header row, see SuppressionRecord for the columns.
+	SuppressionFormatCSV SuppressionFormat = "csv"
+	// SuppressionFormatJSONL has one SuppressionRecord per line.
+	SuppressionFormatJSONL SuppressionFormat = "jsonl"
+)
+
+// DefaultSuppressionImportBatchSize - recipients per create call when SuppressionImportOptions.BatchSize is 0
+const DefaultSuppressionImportBatchSize = 100
+
+var suppressionCSVHeader = []string{"list", "value", "domain_id", "reason", "created_at"}
+
+// suppressionCSVColumns maps the accepted CSV headers to record fields, so lists exported
+// from other providers with an "email" column can be imported as is.
+var suppressionCSVColumns = map[string]string{
+	"list":       "list",
+	"type":       "list",
+	"value":      "value",
+	"email":      "value",
+	"recipient":  "value",
+	"pattern":    "value",
+	"domain_id":  "domain_id",
+	"reason":     "reason",
+	"created_at": "created_at",
+}
+
+// SuppressionRecord - one row of a suppression export or import file, see SuppressionEntry for the entries of the lists
+type SuppressionRecord struct {
+	// List is BlockList, HardBounces, SpamComplaints or Unsubscribes.
+	List SuppressionType `json:"list"`
+	// Value is the email address, or the pattern of a blocklist entry.
+	Value     string    `json:"value"`
+	DomainID  string    `json:"domain_id,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SuppressionExportOptions - modifies the behavior of SuppressionService.Export Method
+type SuppressionExportOptions struct {
+	// Format is SuppressionFormatCSV by default.
+	Format SuppressionFormat
+	// DomainID limits the export to one domain, all domains are exported when empty.
+	DomainID string
+	// Lists are the lists to export, all four by default.
+	Lists []SuppressionType
+}
+
+// SuppressionImportOptions - modifies the behavior of SuppressionService.Import Method
+type SuppressionImportOptions struct {
+	// Format is SuppressionFormatCSV by default.
+	Format SuppressionFormat
+	// List is used for records without a list.
+	List SuppressionType
+	// DomainID is used for records without a domain id.
+	DomainID string
+	// BatchSize is the number of recipients per create call, DefaultSuppressionImportBatchSize by default.
+	BatchSize int
+	// DryRun compares the records with the existing lists without creating anything.
+	DryRun bool
+}
+
+// SuppressionImportResult - outcome of SuppressionService.Import
+type SuppressionImportResult struct {
+	// Created are the records added to the lists, or that would be added in a dry run.
+	Created []SuppressionRecord
+	// Existing are the records already on the lists, or repeated in the input, which were skipped.
+	Existing []SuppressionRecord
+}
+
+// Export - writes every entry of the suppression lists to w, fetching all pages
+func (s *suppressionService) Export(ctx context.Context, w io.Writer, options *SuppressionExportOptions) error {
+	opts := SuppressionExportOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	write, flush, err := newSuppressionWriter(w, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	err = s.eachSuppression(ctx, opts.DomainID, opts.Lists, func(entry SuppressionEntry) error {
+		return write(SuppressionRecord{
+			List:      entry.Type,
+			Value:     entry.Value,
+			DomainID:  entry.Domain.ID,
+			Reason:    entry.Reason,
+			CreatedAt: entry.CreatedAt,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// Import - reads records from r and adds the ones missing from the lists in batches.
+// The records are compared with the existing entries first, which is all a dry run does.
+func (s *suppressionService) Import(ctx context.Context, r io.Reader, options *SuppressionImportOptions) (*SuppressionImportResult, error) {
+	opts := SuppressionImportOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultSuppressionImportBatchSize
+	}
+
+	records, err := readSuppressionRecords(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+	for _, domainID := range suppressionDomains(records) {
+		err := s.eachSuppression(ctx, domainID, suppressionLists(records), func(entry SuppressionEntry) error {
+			existing[suppressionKey(entry.Type, domainID, entry.Value)] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &SuppressionImportResult{}
+	for _, record := range records {
+		key := suppressionKey(record.List, record.DomainID, record.Value)
+		if existing[key] {
+			result.Existing = append(result.Existing, record)
+			continue
+		}
+		existing[key] = true
+		result.Created = append(result.Created, record)
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if err := s.createSuppressions(ctx, result.Created, opts.BatchSize); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// eachSuppression calls fn with every entry of lists, all four when empty.
+func (s *suppressionService) eachSuppression(ctx context.Context, domainID string, lists []SuppressionType, fn func(entry SuppressionEntry) error) error {
+	if len(lists) == 0 {
+		lists = suppressionTypes
+	}
+	options := &SuppressionOptions{DomainID: domainID, Limit: 100}
+
+	for _, list := range lists {
+		it := s.ListAll(ctx, list, options)
+		for it.Next() {
+			if err := fn(it.Item()); err != nil {
+				return err
+			}
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createSuppressions adds records with one create call per batch of each list and domain.
+func (s *suppressionService) createSuppressions(ctx context.Context, records []SuppressionRecord, batchSize int) error {
+	type group struct {
+		list     SuppressionType
+		domainID string
+	}
+
+	var order []group
+	values := map[group][]string{}
+	for _, record := range records {
+		g := group{record.List, record.DomainID}
+		if _, ok := values[g]; !ok {
+			order = append(order, g)
+		}
+		values[g] = append(values[g], record.Value)
+	}
+
+	for _, g := range order {
+		all := values[g]
+		for start := 0; start < len(all); start += batchSize {
+			end := start + batchSize
+			if end > len(all) {
+				end = len(all)
+			}
+			batch := all[start:end]
+
+			options := &CreateSuppressionOptions{DomainID: g.domainID}
+			for _, value := range batch {
+				if g.list == BlockList && strings.Contains(value, "*") {
+					options.Patterns = append(options.Patterns, value)
+				} else {
+					options.Recipients = append(options.Recipients, value)
+				}
+			}
+			if _, _, err := s.Create(ctx, g.list, options); err != nil {
+				return fmt.Errorf("mailersend: importing %s of domain %s: %w", g.list, g.domainID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func newSuppressionWriter(w io.Writer, format SuppressionFormat) (write func(record SuppressionRecord) error, flush func() error, err error) {
+	switch format {
+	case "", SuppressionFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(suppressionCSVHeader); err != nil {
+			return nil, nil, err
+		}
+
+		write = func(record SuppressionRecord) error {
+			createdAt := ""
+			if !record.CreatedAt.IsZero() {
+				createdAt = record.CreatedAt.Format(time.RFC3339)
+			}
+			return cw.Write([]string{string(record.List), record.Value, record.DomainID, record.Reason, createdAt})
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+
+		return write, flush, nil
+	case SuppressionFormatJSONL:
+		encoder := json.NewEncoder(w)
+		return func(record SuppressionRecord) error { return encoder.Encode(record) }, func() error { return nil }, nil
+	default:
+		return nil, nil, fmt.Errorf("mailersend: unknown suppression format %q", format)
+	}
+}
+
+// readSuppressionRecords reads and checks all records, filling in the default list and domain.
+func readSuppressionRecords(r io.Reader, opts SuppressionImportOptions) ([]SuppressionRecord, error) {
+	var records []SuppressionRecord
+	add := func(line int, record SuppressionRecord) error {
+		record.Value = strings.TrimSpace(record.Value)
+		if record.List == "" {
+			record.List = opts.List
+		}
+		if record.DomainID == "" {
+			record.DomainID = opts.DomainID
+		}
+
+		switch {
+		case record.Value == "":
+			return fmt.Errorf("mailersend: suppression record %d: value is empty", line)
+		case record.DomainID == "":
+			return fmt.Errorf("mailersend: suppression record %d: domain_id is required", line)
+		case !record.List.valid():
+			return fmt.Errorf("mailersend: suppression record %d: unknown list %q", line, record.List)
+		case record.List != BlockList && !validEmail(record.Value):
+			return fmt.Errorf("mailersend: suppression record %d: %q is not a valid email address", line, record.Value)
+		}
+
+		records = append(records, record)
+		return nil
+	}
+
+	switch opts.Format {
+	case "", SuppressionFormatCSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+
+		header, err := cr.Read()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		columns := map[string]int{}
+		for i, name := range header {
+			if field, ok := suppressionCSVColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+				columns[field] = i
+			}
+		}
+		if _, ok := columns["value"]; !ok {
+			return nil, errors.New("mailersend: suppression csv has no email or value column")
+		}
+
+		for line := 2; ; line++ {
+			row, err := cr.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			column := func(field string) string {
+				if i, ok := columns[field]; ok && i < len(row) {
+					return strings.TrimSpace(row[i])
+				}
+				return ""
+			}
+
+			record := SuppressionRecord{
+				List:     SuppressionType(column("list")),
+				Value:    column("value"),
+				DomainID: column("domain_id"),
+				Reason:   column("reason"),
+			}
+			if createdAt := column("created_at"); createdAt != "" {
+				if record.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+					return nil, fmt.Errorf("mailersend: suppression record %d: %w", line, err)
+				}
+			}
+			if err := add(line, record); err != nil {
+				return nil, err
+			}
+		}
+	case SuppressionFormatJSONL:
+		scanner := bufio.NewScanner(r)
+		for line := 1; scanner.Scan(); line++ {
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+
+			var record SuppressionRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				return nil, fmt.Errorf("mailersend: suppression record %d: %w", line, err)
+			}
+			if err := add(line, record); err != nil {
+				return nil, err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("mailersend: unknown suppression format %q", opts.Format)
+	}
+
+	return records, nil
+}
+
+func suppressionDomains(records []SuppressionRecord) []string {
+	seen := map[string]bool{}
+	var domains []string
+	for _, record := range records {
+		if !seen[record.DomainID] {
+			seen[record.DomainID] = true
+			domains = append(domains, record.DomainID)
+		}
+	}
+
+	return domains
+}
+
+func suppressionLists(records []SuppressionRecord) []SuppressionType {
+	seen := map[SuppressionType]bool{}
+	var lists []SuppressionType
+	for _, record := range records {
+		if !seen[record.List] {
+			seen[record.List] = true
+			lists = append(lists, record.List)
+		}
+	}
+
+	return lists
+}
+
+func suppressionKey(list SuppressionType, domainID string, value string) string {
+	return string(list) + "|" + domainID + "|" + strings.ToLower(value)
+}
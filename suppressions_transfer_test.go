@@ -0,0 +1,93 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/mailersend/mailersend-go/mailersendtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressionExport(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	domain := srv.AddDomain("example.com")
+	srv.AddSuppression(mailersend.HardBounces, domain.ID, "bounced@client.com")
+	srv.AddSuppression(mailersend.BlockList, domain.ID, "*@blocked.com")
+	srv.AddSuppression(mailersend.Unsubscribes, domain.ID, "gone@client.com")
+
+	ms := srv.NewMailersend()
+
+	var out bytes.Buffer
+	assert.NoError(t, ms.Suppression.Export(context.TODO(), &out, &mailersend.SuppressionExportOptions{
+		Lists: []mailersend.SuppressionType{mailersend.BlockList, mailersend.HardBounces},
+	}))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, "list,value,domain_id,reason,created_at", lines[0])
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[1], "blocklist,*@blocked.com,"+domain.ID+",,"))
+	assert.Contains(t, lines[2], "hard-bounces,bounced@client.com,"+domain.ID+",550 5.1.1")
+
+	out.Reset()
+	assert.NoError(t, ms.Suppression.Export(context.TODO(), &out, &mailersend.SuppressionExportOptions{Format: mailersend.SuppressionFormatJSONL}))
+	assert.Equal(t, 3, strings.Count(out.String(), "\n"))
+
+	// Importing the export again finds nothing to add.
+	result, err := ms.Suppression.Import(context.TODO(), &out, &mailersend.SuppressionImportOptions{
+		Format: mailersend.SuppressionFormatJSONL,
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Len(t, result.Existing, 3)
+}
+
+func TestSuppressionImport(t *testing.T) {
+	srv := mailersendtest.NewServer()
+	defer srv.Close()
+
+	domain := srv.AddDomain("example.com")
+	srv.AddSuppression(mailersend.HardBounces, domain.ID, "bounced@client.com")
+
+	ms := srv.NewMailersend()
+	input := "Email,Reason\nfirst@client.com,bounced\nBOUNCED@client.com,\nsecond@client.com,\nfirst@client.com,\n"
+	options := &mailersend.SuppressionImportOptions{
+		List:      mailersend.HardBounces,
+		DomainID:  domain.ID,
+		BatchSize: 1,
+		DryRun:    true,
+	}
+
+	result, err := ms.Suppression.Import(context.TODO(), strings.NewReader(input), options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first@client.com", "second@client.com"}, suppressionValues(result.Created))
+	assert.Equal(t, []string{"BOUNCED@client.com", "first@client.com"}, suppressionValues(result.Existing))
+
+	bounces, err := ms.Suppression.ListAllHardBounces(context.TODO(), nil).All()
+	assert.NoError(t, err)
+	assert.Len(t, bounces, 1)
+
+	options.DryRun = false
+	_, err = ms.Suppression.Import(context.TODO(), strings.NewReader(input), options)
+	assert.NoError(t, err)
+
+	bounces, err = ms.Suppression.ListAllHardBounces(context.TODO(), nil).All()
+	assert.NoError(t, err)
+	assert.Len(t, bounces, 3)
+
+	_, err = ms.Suppression.Import(context.TODO(), strings.NewReader("email\nnot-an-email\n"), options)
+	assert.ErrorContains(t, err, "record 2")
+}
+
+func suppressionValues(records []mailersend.SuppressionRecord) []string {
+	var values []string
+	for _, record := range records {
+		values = append(values, record.Value)
+	}
+	return values
+}
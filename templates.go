@@ -3,7 +3,9 @@ package mailersend
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"net/http"
+	"os"
 	"time"
 )
 
@@ -11,7 +13,10 @@ const templateBasePath = "/templates"
 
 type TemplateService interface {
 	List(ctx context.Context, options *ListTemplateOptions) (*TemplateRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListTemplateOptions) *Iterator[Template]
 	Get(ctx context.Context, templateID string) (*SingleTemplateRoot, *Response, error)
+	Create(ctx context.Context, options *CreateTemplateOptions) (*SingleTemplateRoot, *Response, error)
+	Update(ctx context.Context, options *UpdateTemplateOptions) (*SingleTemplateRoot, *Response, error)
 	Delete(ctx context.Context, templateID string) (*Response, error)
 }
 
@@ -65,6 +70,91 @@ type ListTemplateOptions struct {
 	Limit    int    `url:"limit,omitempty"`
 }
 
+// CreateTemplateOptions - modifies the behavior of *TemplateService.Create Method
+type CreateTemplateOptions struct {
+	Name       string   `json:"name"`
+	DomainID   string   `json:"domain_id,omitempty"`
+	CategoryID string   `json:"category_id,omitempty"`
+	HTML       string   `json:"html"`
+	Text       string   `json:"text,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+// UpdateTemplateOptions - modifies the behavior of *TemplateService.Update Method
+type UpdateTemplateOptions struct {
+	TemplateID string   `json:"-"`
+	Name       string   `json:"name,omitempty"`
+	DomainID   string   `json:"domain_id,omitempty"`
+	CategoryID string   `json:"category_id,omitempty"`
+	HTML       string   `json:"html,omitempty"`
+	Text       string   `json:"text,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+// LoadFiles - sets HTML and Text from files, see ReadTemplateFiles
+func (o *CreateTemplateOptions) LoadFiles(fsys fs.FS, htmlPath string, textPath string) error {
+	html, text, err := ReadTemplateFiles(fsys, htmlPath, textPath)
+	if err != nil {
+		return err
+	}
+	if htmlPath != "" {
+		o.HTML = html
+	}
+	if textPath != "" {
+		o.Text = text
+	}
+
+	return nil
+}
+
+// LoadFiles - sets HTML and Text from files, see ReadTemplateFiles
+func (o *UpdateTemplateOptions) LoadFiles(fsys fs.FS, htmlPath string, textPath string) error {
+	html, text, err := ReadTemplateFiles(fsys, htmlPath, textPath)
+	if err != nil {
+		return err
+	}
+	if htmlPath != "" {
+		o.HTML = html
+	}
+	if textPath != "" {
+		o.Text = text
+	}
+
+	return nil
+}
+
+// ReadTemplateFiles - reads the HTML and text of a template from fsys, like an embed.FS.
+// A nil fsys reads the paths from disk. Empty paths are skipped.
+func ReadTemplateFiles(fsys fs.FS, htmlPath string, textPath string) (html string, text string, err error) {
+	read := func(path string) (string, error) {
+		if path == "" {
+			return "", nil
+		}
+
+		var data []byte
+		var err error
+		if fsys == nil {
+			data, err = os.ReadFile(path)
+		} else {
+			data, err = fs.ReadFile(fsys, path)
+		}
+		if err != nil {
+			return "", fmt.Errorf("mailersend: reading template file: %w", err)
+		}
+
+		return string(data), nil
+	}
+
+	if html, err = read(htmlPath); err != nil {
+		return "", "", err
+	}
+	if text, err = read(textPath); err != nil {
+		return "", "", err
+	}
+
+	return html, text, nil
+}
+
 func (s *templateService) List(ctx context.Context, options *ListTemplateOptions) (*TemplateRoot, *Response, error) {
 	req, err := s.client.newRequest(http.MethodGet, templateBasePath, options)
 	if err != nil {
@@ -72,7 +162,7 @@ func (s *templateService) List(ctx context.Context, options *ListTemplateOptions
 	}
 
 	root := new(TemplateRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Template.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -80,6 +170,24 @@ func (s *templateService) List(ctx context.Context, options *ListTemplateOptions
 	return root, res, nil
 }
 
+func (s *templateService) ListAll(ctx context.Context, options *ListTemplateOptions) *Iterator[Template] {
+	opts := ListTemplateOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]Template, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 func (s *templateService) Get(ctx context.Context, templateID string) (*SingleTemplateRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", templateBasePath, templateID)
 
@@ -89,7 +197,39 @@ func (s *templateService) Get(ctx context.Context, templateID string) (*SingleTe
 	}
 
 	root := new(SingleTemplateRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Template.Get", req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
+
+func (s *templateService) Create(ctx context.Context, options *CreateTemplateOptions) (*SingleTemplateRoot, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPost, templateBasePath, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(SingleTemplateRoot)
+	res, err := s.client.do(ctx, "Template.Create", req, root)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return root, res, nil
+}
+
+func (s *templateService) Update(ctx context.Context, options *UpdateTemplateOptions) (*SingleTemplateRoot, *Response, error) {
+	path := fmt.Sprintf("%s/%s", templateBasePath, options.TemplateID)
+
+	req, err := s.client.newRequest(http.MethodPut, path, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(SingleTemplateRoot)
+	res, err := s.client.do(ctx, "Template.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -105,5 +245,5 @@ func (s *templateService) Delete(ctx context.Context, templateID string) (*Respo
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Template.Delete", req, nil)
 }
@@ -0,0 +1,86 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTemplateFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/welcome.html": {Data: []byte("<h1>Welcome {{ name }}</h1>")},
+		"templates/welcome.txt":  {Data: []byte("Welcome {{ name }}")},
+	}
+
+	options := &mailersend.CreateTemplateOptions{
+		Name:       "Welcome",
+		DomainID:   "domain-id",
+		CategoryID: "category-id",
+		Tags:       []string{"onboarding"},
+	}
+	err := options.LoadFiles(fsys, "templates/welcome.html", "templates/welcome.txt")
+	assert.NoError(t, err)
+
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://api.mailersend.com/v1/templates", req.URL.String())
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"name":        "Welcome",
+			"domain_id":   "domain-id",
+			"category_id": "category-id",
+			"html":        "<h1>Welcome {{ name }}</h1>",
+			"text":        "Welcome {{ name }}",
+			"tags":        []interface{}{"onboarding"},
+		}, body)
+
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": {"id": "template-id", "name": "Welcome"}}`)),
+		}
+	}))
+
+	root, _, err := ms.Template.Create(context.TODO(), options)
+	assert.NoError(t, err)
+	assert.Equal(t, "template-id", root.Data.ID)
+}
+
+func TestUpdateTemplateFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "welcome.html")
+	assert.NoError(t, os.WriteFile(path, []byte("<h1>Hello</h1>"), 0o600))
+
+	options := &mailersend.UpdateTemplateOptions{TemplateID: "template-id", Text: "Hello"}
+	assert.NoError(t, options.LoadFiles(nil, path, ""))
+
+	ms := mailersend.NewMailersend(testKey)
+	ms.SetClient(NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "https://api.mailersend.com/v1/templates/template-id", req.URL.String())
+
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"html": "<h1>Hello</h1>", "text": "Hello"}`, string(body))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": {"id": "template-id"}}`)),
+		}
+	}))
+
+	_, _, err := ms.Template.Update(context.TODO(), options)
+	assert.NoError(t, err)
+
+	_, _, err = mailersend.ReadTemplateFiles(fstest.MapFS{}, "missing.html", "")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -51,7 +51,7 @@ func (s *tokenService) Create(ctx context.Context, options *CreateTokenOptions)
 	}
 
 	root := new(TokenRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Token.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -68,7 +68,7 @@ func (s *tokenService) Update(ctx context.Context, options *UpdateTokenOptions)
 	}
 
 	root := new(TokenRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Token.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -84,5 +84,5 @@ func (s *tokenService) Delete(ctx context.Context, tokenID string) (*Response, e
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Token.Delete", req, nil)
 }
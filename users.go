@@ -11,6 +11,7 @@ const usersBasePath = "/users"
 // UserService defines the interface for user management operations
 type UserService interface {
 	List(ctx context.Context, options *ListUserOptions) (*UserRoot, *Response, error)
+	ListAll(ctx context.Context, options *ListUserOptions) *Iterator[User]
 	Get(ctx context.Context, userID string) (*SingleUserRoot, *Response, error)
 	Invite(ctx context.Context, options *InviteUserOptions) (*SingleUserRoot, *Response, error)
 	Update(ctx context.Context, userID string, options *UpdateUserOptions) (*SingleUserRoot, *Response, error)
@@ -69,7 +70,7 @@ func (s *userService) List(ctx context.Context, options *ListUserOptions) (*User
 	}
 
 	users := new(UserRoot)
-	res, err := s.client.do(ctx, req, users)
+	res, err := s.client.do(ctx, "User.List", req, users)
 	if err != nil {
 		return nil, res, err
 	}
@@ -77,6 +78,24 @@ func (s *userService) List(ctx context.Context, options *ListUserOptions) (*User
 	return users, res, nil
 }
 
+func (s *userService) ListAll(ctx context.Context, options *ListUserOptions) *Iterator[User] {
+	opts := ListUserOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	return newIterator(ctx, opts.Page, func(ctx context.Context, page int) ([]User, Links, *Response, error) {
+		opts.Page = page
+
+		root, res, err := s.List(ctx, &opts)
+		if err != nil {
+			return nil, Links{}, res, err
+		}
+
+		return root.Data, root.Links, res, nil
+	})
+}
+
 // Get retrieves a single user by ID
 func (s *userService) Get(ctx context.Context, userID string) (*SingleUserRoot, *Response, error) {
 	path := fmt.Sprintf("%s/%s", usersBasePath, userID)
@@ -87,7 +106,7 @@ func (s *userService) Get(ctx context.Context, userID string) (*SingleUserRoot,
 	}
 
 	user := new(SingleUserRoot)
-	res, err := s.client.do(ctx, req, user)
+	res, err := s.client.do(ctx, "User.Get", req, user)
 	if err != nil {
 		return nil, res, err
 	}
@@ -103,7 +122,7 @@ func (s *userService) Invite(ctx context.Context, options *InviteUserOptions) (*
 	}
 
 	user := new(SingleUserRoot)
-	res, err := s.client.do(ctx, req, user)
+	res, err := s.client.do(ctx, "User.Invite", req, user)
 	if err != nil {
 		return nil, res, err
 	}
@@ -121,7 +140,7 @@ func (s *userService) Update(ctx context.Context, userID string, options *Update
 	}
 
 	user := new(SingleUserRoot)
-	res, err := s.client.do(ctx, req, user)
+	res, err := s.client.do(ctx, "User.Update", req, user)
 	if err != nil {
 		return nil, res, err
 	}
@@ -138,5 +157,5 @@ func (s *userService) Delete(ctx context.Context, userID string) (*Response, err
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "User.Delete", req, nil)
 }
@@ -0,0 +1,240 @@
+package mailersend
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// WebhookSignatureHeader - header MailerSend uses to sign webhook requests
+const WebhookSignatureHeader = "Signature"
+
+// WebhookEvent - implemented by every parsed webhook payload
+type WebhookEvent interface {
+	Payload() *WebhookPayload
+}
+
+// WebhookPayload - fields shared by every webhook payload
+type WebhookPayload struct {
+	Type      string    `json:"type"`
+	DomainID  string    `json:"domain_id"`
+	WebhookID string    `json:"webhook_id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Raw is the unmodified request body the event was parsed from.
+	Raw []byte `json:"-"`
+}
+
+// Payload - returns the fields shared by every webhook payload
+func (p *WebhookPayload) Payload() *WebhookPayload {
+	return p
+}
+
+// ActivityEvent - payload of the activity.* webhook events
+type ActivityEvent struct {
+	WebhookPayload
+	Data ActivityEventData `json:"data"`
+}
+
+type ActivityEventData struct {
+	Object     string              `json:"object"`
+	ID         string              `json:"id"`
+	Type       string              `json:"type"`
+	CreatedAt  time.Time           `json:"created_at"`
+	Email      ActivityEventEmail  `json:"email"`
+	Morph      *ActivityEventMorph `json:"morph"`
+	TemplateID string              `json:"template_id"`
+}
+
+type ActivityEventEmail struct {
+	Object    string                 `json:"object"`
+	ID        string                 `json:"id"`
+	CreatedAt time.Time              `json:"created_at"`
+	From      string                 `json:"from"`
+	Subject   string                 `json:"subject"`
+	Status    string                 `json:"status"`
+	Tags      []string               `json:"tags"`
+	Headers   interface{}            `json:"headers"`
+	Message   ActivityEventMessage   `json:"message"`
+	Recipient ActivityEventRecipient `json:"recipient"`
+}
+
+type ActivityEventMessage struct {
+	Object    string    `json:"object"`
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ActivityEventRecipient struct {
+	Object    string    `json:"object"`
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ActivityEventMorph - event specific details such as the opened IP, the clicked URL or the bounce reason
+type ActivityEventMorph struct {
+	Object         string                  `json:"object"`
+	ID             string                  `json:"id"`
+	CreatedAt      time.Time               `json:"created_at"`
+	IP             string                  `json:"ip,omitempty"`
+	URL            string                  `json:"url,omitempty"`
+	Reason         string                  `json:"reason,omitempty"`
+	ReadableReason string                  `json:"readable_reason,omitempty"`
+	Recipient      *ActivityEventRecipient `json:"recipient,omitempty"`
+}
+
+// SenderIdentityEvent - payload of the sender_identity.verified webhook event
+type SenderIdentityEvent struct {
+	WebhookPayload
+	Data Identity `json:"data"`
+}
+
+// MaintenanceEvent - payload of the maintenance.* webhook events
+type MaintenanceEvent struct {
+	WebhookPayload
+	Data MaintenanceEventData `json:"data"`
+}
+
+type MaintenanceEventData struct {
+	Object   string    `json:"object"`
+	ID       string    `json:"id"`
+	Message  string    `json:"message"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// InboundForwardFailedEvent - payload of the inbound_forward.failed webhook event
+type InboundForwardFailedEvent struct {
+	WebhookPayload
+	Data InboundForwardFailedData `json:"data"`
+}
+
+type InboundForwardFailedData struct {
+	Object    string    `json:"object"`
+	ID        string    `json:"id"`
+	InboundID string    `json:"inbound_id"`
+	Forward   Forwards  `json:"forward"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EmailSingleVerifiedEvent - payload of the email_single.verified webhook event
+type EmailSingleVerifiedEvent struct {
+	WebhookPayload
+	Data EmailSingleVerifiedData `json:"data"`
+}
+
+type EmailSingleVerifiedData struct {
+	Object    string    `json:"object"`
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EmailListVerifiedEvent - payload of the email_list.verified webhook event
+type EmailListVerifiedEvent struct {
+	WebhookPayload
+	Data EmailVerification `json:"data"`
+}
+
+// BulkEmailCompletedEvent - payload of the bulk_email.completed webhook event
+type BulkEmailCompletedEvent struct {
+	WebhookPayload
+	Data BulkEmailData `json:"data"`
+}
+
+// RecipientOnHoldEvent - payload of the recipient.on_hold_* webhook events
+type RecipientOnHoldEvent struct {
+	WebhookPayload
+	Data RecipientOnHoldData `json:"data"`
+}
+
+type RecipientOnHoldData struct {
+	Object    string    `json:"object"`
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UnknownWebhookEvent - payload of an event type this version of the SDK does not know about
+type UnknownWebhookEvent struct {
+	WebhookPayload
+	Data json.RawMessage `json:"data"`
+}
+
+// ParseWebhookEvent - parses a webhook request body into the typed event matching its type.
+// Unrecognised event types are returned as *UnknownWebhookEvent.
+func ParseWebhookEvent(body []byte) (WebhookEvent, error) {
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Type == "" {
+		return nil, errors.New("mailersend: webhook payload has no type")
+	}
+
+	var event WebhookEvent
+	switch payload.Type {
+	case EventActivitySent,
+		EventActivityDelivered,
+		EventActivitySoftBounced,
+		EventActivityHardBounced,
+		EventActivityDeferred,
+		EventActivityOpened,
+		EventActivityOpenedUnique,
+		EventActivityClicked,
+		EventActivityClickedUnique,
+		EventActivityUnsubscribed,
+		EventActivitySpamComplaint,
+		EventActivitySurveyOpened,
+		EventActivitySurveySubmitted:
+		event = new(ActivityEvent)
+	case EventSenderIdentityVerified:
+		event = new(SenderIdentityEvent)
+	case EventMaintenanceStart, EventMaintenanceEnd:
+		event = new(MaintenanceEvent)
+	case EventInboundForwardFailed:
+		event = new(InboundForwardFailedEvent)
+	case EventEmailSingleVerified:
+		event = new(EmailSingleVerifiedEvent)
+	case EventEmailListVerified:
+		event = new(EmailListVerifiedEvent)
+	case EventBulkEmailCompleted:
+		event = new(BulkEmailCompletedEvent)
+	case EventRecipientOnHoldAdded, EventRecipientOnHoldRemoved:
+		event = new(RecipientOnHoldEvent)
+	default:
+		event = new(UnknownWebhookEvent)
+	}
+
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+	event.Payload().Raw = body
+
+	return event, nil
+}
+
+// ComputeWebhookSignature - returns the signature MailerSend sends for body when signed with secret
+func ComputeWebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature - reports whether signature, the value of the Signature header,
+// is a valid signature of body for the webhook signing secret.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	expected := ComputeWebhookSignature(secret, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
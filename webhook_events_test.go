@@ -0,0 +1,102 @@
+package mailersend_test
+
+import (
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+const webhookSecret = "webhook-signing-secret"
+
+var deliveredPayload = []byte(`{
+	"type": "activity.delivered",
+	"domain_id": "domain-id",
+	"created_at": "2024-01-01T12:00:00.000000Z",
+	"webhook_id": "webhook-id",
+	"url": "https://example.com/webhook",
+	"data": {
+		"object": "activity",
+		"id": "activity-id",
+		"type": "delivered",
+		"created_at": "2024-01-01T12:00:00.000000Z",
+		"email": {
+			"object": "email",
+			"id": "email-id",
+			"created_at": "2024-01-01T11:59:00.000000Z",
+			"from": "sender@example.com",
+			"subject": "Hello",
+			"status": "delivered",
+			"tags": ["welcome"],
+			"headers": null,
+			"message": {"object": "message", "id": "message-id", "created_at": "2024-01-01T11:59:00.000000Z"},
+			"recipient": {"object": "recipient", "id": "recipient-id", "email": "to@example.com", "created_at": "2024-01-01T11:59:00.000000Z"}
+		},
+		"morph": null,
+		"template_id": ""
+	}
+}`)
+
+func TestCanParseActivityWebhookEvent(t *testing.T) {
+	event, err := mailersend.ParseWebhookEvent(deliveredPayload)
+	assert.NoError(t, err)
+
+	activity, ok := event.(*mailersend.ActivityEvent)
+	assert.True(t, ok)
+	assert.Equal(t, mailersend.EventActivityDelivered, activity.Type)
+	assert.Equal(t, "domain-id", activity.DomainID)
+	assert.Equal(t, "message-id", activity.Data.Email.Message.ID)
+	assert.Equal(t, "to@example.com", activity.Data.Email.Recipient.Email)
+	assert.Equal(t, []string{"welcome"}, activity.Data.Email.Tags)
+	assert.Nil(t, activity.Data.Morph)
+	assert.Equal(t, deliveredPayload, event.Payload().Raw)
+}
+
+func TestCanParseWebhookEventMorph(t *testing.T) {
+	body := []byte(`{
+		"type": "activity.clicked",
+		"data": {
+			"type": "clicked",
+			"morph": {"object": "click", "id": "click-id", "ip": "127.0.0.1", "url": "https://example.com"}
+		}
+	}`)
+
+	event, err := mailersend.ParseWebhookEvent(body)
+	assert.NoError(t, err)
+
+	activity := event.(*mailersend.ActivityEvent)
+	assert.Equal(t, "https://example.com", activity.Data.Morph.URL)
+	assert.Equal(t, "127.0.0.1", activity.Data.Morph.IP)
+}
+
+func TestCanParseOtherWebhookEvents(t *testing.T) {
+	event, err := mailersend.ParseWebhookEvent([]byte(`{"type": "bulk_email.completed", "data": {"id": "bulk-id", "state": "completed"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "bulk-id", event.(*mailersend.BulkEmailCompletedEvent).Data.ID)
+
+	event, err = mailersend.ParseWebhookEvent([]byte(`{"type": "recipient.on_hold_added", "data": {"email": "to@example.com"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "to@example.com", event.(*mailersend.RecipientOnHoldEvent).Data.Email)
+
+	event, err = mailersend.ParseWebhookEvent([]byte(`{"type": "something.new", "data": {"id": "1"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "something.new", event.Payload().Type)
+	assert.JSONEq(t, `{"id": "1"}`, string(event.(*mailersend.UnknownWebhookEvent).Data))
+}
+
+func TestParseWebhookEventRejectsInvalidPayload(t *testing.T) {
+	_, err := mailersend.ParseWebhookEvent([]byte(`not json`))
+	assert.Error(t, err)
+
+	_, err = mailersend.ParseWebhookEvent([]byte(`{"data": {}}`))
+	assert.Error(t, err)
+}
+
+func TestCanVerifyWebhookSignature(t *testing.T) {
+	signature := mailersend.ComputeWebhookSignature(webhookSecret, deliveredPayload)
+
+	assert.True(t, mailersend.VerifyWebhookSignature(webhookSecret, deliveredPayload, signature))
+	assert.False(t, mailersend.VerifyWebhookSignature("other-secret", deliveredPayload, signature))
+	assert.False(t, mailersend.VerifyWebhookSignature(webhookSecret, append(deliveredPayload, ' '), signature))
+	assert.False(t, mailersend.VerifyWebhookSignature(webhookSecret, deliveredPayload, ""))
+}
@@ -0,0 +1,332 @@
+package mailersend
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultWebhookTolerance - how old a delivery may be before WebhookHandler rejects it as stale
+const DefaultWebhookTolerance = time.Hour
+
+const maxWebhookBodySize = 1 << 20
+
+// WebhookEventFunc - callback invoked with a verified webhook event
+type WebhookEventFunc func(ctx context.Context, event WebhookEvent) error
+
+// WebhookDedupStore - remembers which webhook deliveries have already been processed
+type WebhookDedupStore interface {
+	// Add records key for ttl and reports whether it was not already present.
+	Add(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Remove forgets key so a retried delivery is processed again.
+	Remove(ctx context.Context, key string) error
+}
+
+// WebhookHandler - http.Handler that verifies, de-duplicates and dispatches MailerSend webhooks.
+//
+// Callbacks must be registered before the handler starts serving requests. The handler
+// responds with 2xx when a delivery was processed or intentionally ignored, and with a
+// non-2xx status when MailerSend should retry it.
+type WebhookHandler struct {
+	secret    string
+	tolerance time.Duration
+	store     WebhookDedupStore
+	callbacks map[string][]WebhookEventFunc
+	catchAll  []WebhookEventFunc
+	now       func() time.Time
+}
+
+// NewWebhookHandler - creates a handler for webhooks signed with secret
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{
+		secret:    secret,
+		tolerance: DefaultWebhookTolerance,
+		store:     NewMemoryWebhookDedupStore(),
+		callbacks: make(map[string][]WebhookEventFunc),
+		now:       time.Now,
+	}
+}
+
+// SetTolerance - Set how old a delivery may be before it is rejected as stale, 0 disables the check
+func (h *WebhookHandler) SetTolerance(tolerance time.Duration) {
+	h.tolerance = tolerance
+}
+
+// SetDedupStore - Set the store used to detect duplicate deliveries, nil disables de-duplication
+func (h *WebhookHandler) SetDedupStore(store WebhookDedupStore) {
+	h.store = store
+}
+
+// On - register a callback for eventType
+func (h *WebhookHandler) On(eventType string, fn WebhookEventFunc) {
+	h.callbacks[eventType] = append(h.callbacks[eventType], fn)
+}
+
+// OnAny - register a callback for every event, including unknown event types
+func (h *WebhookHandler) OnAny(fn WebhookEventFunc) {
+	h.catchAll = append(h.catchAll, fn)
+}
+
+func (h *WebhookHandler) onActivity(eventType string, fn func(context.Context, *ActivityEvent) error) {
+	h.On(eventType, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*ActivityEvent))
+	})
+}
+
+// OnSent - register a callback for activity.sent
+func (h *WebhookHandler) OnSent(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivitySent, fn)
+}
+
+// OnDelivered - register a callback for activity.delivered
+func (h *WebhookHandler) OnDelivered(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivityDelivered, fn)
+}
+
+// OnSoftBounced - register a callback for activity.soft_bounced
+func (h *WebhookHandler) OnSoftBounced(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivitySoftBounced, fn)
+}
+
+// OnHardBounced - register a callback for activity.hard_bounced
+func (h *WebhookHandler) OnHardBounced(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivityHardBounced, fn)
+}
+
+// OnDeferred - register a callback for activity.deferred
+func (h *WebhookHandler) OnDeferred(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivityDeferred, fn)
+}
+
+// OnOpened - register a callback for activity.opened
+func (h *WebhookHandler) OnOpened(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivityOpened, fn)
+}
+
+// OnOpenedUnique - register a callback for activity.opened_unique
+func (h *WebhookHandler) OnOpenedUnique(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivityOpenedUnique, fn)
+}
+
+// OnClicked - register a callback for activity.clicked
+func (h *WebhookHandler) OnClicked(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivityClicked, fn)
+}
+
+// OnClickedUnique - register a callback for activity.clicked_unique
+func (h *WebhookHandler) OnClickedUnique(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivityClickedUnique, fn)
+}
+
+// OnUnsubscribed - register a callback for activity.unsubscribed
+func (h *WebhookHandler) OnUnsubscribed(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivityUnsubscribed, fn)
+}
+
+// OnSpamComplaint - register a callback for activity.spam_complaint
+func (h *WebhookHandler) OnSpamComplaint(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivitySpamComplaint, fn)
+}
+
+// OnSurveyOpened - register a callback for activity.survey_opened
+func (h *WebhookHandler) OnSurveyOpened(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivitySurveyOpened, fn)
+}
+
+// OnSurveySubmitted - register a callback for activity.survey_submitted
+func (h *WebhookHandler) OnSurveySubmitted(fn func(context.Context, *ActivityEvent) error) {
+	h.onActivity(EventActivitySurveySubmitted, fn)
+}
+
+// OnSenderIdentityVerified - register a callback for sender_identity.verified
+func (h *WebhookHandler) OnSenderIdentityVerified(fn func(context.Context, *SenderIdentityEvent) error) {
+	h.On(EventSenderIdentityVerified, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*SenderIdentityEvent))
+	})
+}
+
+// OnMaintenanceStart - register a callback for maintenance.start
+func (h *WebhookHandler) OnMaintenanceStart(fn func(context.Context, *MaintenanceEvent) error) {
+	h.On(EventMaintenanceStart, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*MaintenanceEvent))
+	})
+}
+
+// OnMaintenanceEnd - register a callback for maintenance.end
+func (h *WebhookHandler) OnMaintenanceEnd(fn func(context.Context, *MaintenanceEvent) error) {
+	h.On(EventMaintenanceEnd, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*MaintenanceEvent))
+	})
+}
+
+// OnInboundForwardFailed - register a callback for inbound_forward.failed
+func (h *WebhookHandler) OnInboundForwardFailed(fn func(context.Context, *InboundForwardFailedEvent) error) {
+	h.On(EventInboundForwardFailed, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*InboundForwardFailedEvent))
+	})
+}
+
+// OnEmailSingleVerified - register a callback for email_single.verified
+func (h *WebhookHandler) OnEmailSingleVerified(fn func(context.Context, *EmailSingleVerifiedEvent) error) {
+	h.On(EventEmailSingleVerified, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*EmailSingleVerifiedEvent))
+	})
+}
+
+// OnEmailListVerified - register a callback for email_list.verified
+func (h *WebhookHandler) OnEmailListVerified(fn func(context.Context, *EmailListVerifiedEvent) error) {
+	h.On(EventEmailListVerified, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*EmailListVerifiedEvent))
+	})
+}
+
+// OnBulkEmailCompleted - register a callback for bulk_email.completed
+func (h *WebhookHandler) OnBulkEmailCompleted(fn func(context.Context, *BulkEmailCompletedEvent) error) {
+	h.On(EventBulkEmailCompleted, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*BulkEmailCompletedEvent))
+	})
+}
+
+// OnRecipientOnHoldAdded - register a callback for recipient.on_hold_added
+func (h *WebhookHandler) OnRecipientOnHoldAdded(fn func(context.Context, *RecipientOnHoldEvent) error) {
+	h.On(EventRecipientOnHoldAdded, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*RecipientOnHoldEvent))
+	})
+}
+
+// OnRecipientOnHoldRemoved - register a callback for recipient.on_hold_removed
+func (h *WebhookHandler) OnRecipientOnHoldRemoved(fn func(context.Context, *RecipientOnHoldEvent) error) {
+	h.On(EventRecipientOnHoldRemoved, func(ctx context.Context, event WebhookEvent) error {
+		return fn(ctx, event.(*RecipientOnHoldEvent))
+	})
+}
+
+// ServeHTTP - verifies the delivery and runs the registered callbacks.
+//
+// Invalid signatures get 401 and malformed or stale payloads get 400. Duplicates and
+// events without callbacks are acknowledged with 200 without running anything. A callback
+// error results in 500 so that MailerSend retries the delivery.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	signature := r.Header.Get(WebhookSignatureHeader)
+	if !VerifyWebhookSignature(h.secret, body, signature) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	event, err := ParseWebhookEvent(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	payload := event.Payload()
+	if h.tolerance > 0 && !payload.CreatedAt.IsZero() && h.now().Sub(payload.CreatedAt) > h.tolerance {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	callbacks := make([]WebhookEventFunc, 0, len(h.callbacks[payload.Type])+len(h.catchAll))
+	callbacks = append(callbacks, h.callbacks[payload.Type]...)
+	callbacks = append(callbacks, h.catchAll...)
+	if len(callbacks) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := r.Context()
+
+	// The signature is an HMAC of the body, so it identifies retries of the same delivery.
+	if h.store != nil {
+		added, err := h.store.Add(ctx, signature, h.dedupTTL())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if !added {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	for _, fn := range callbacks {
+		if err := fn(ctx, event); err != nil {
+			if h.store != nil {
+				_ = h.store.Remove(ctx, signature)
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *WebhookHandler) dedupTTL() time.Duration {
+	if h.tolerance > 0 {
+		return h.tolerance
+	}
+	return DefaultWebhookTolerance
+}
+
+// MemoryWebhookDedupStore - in-memory WebhookDedupStore, suitable for a single process
+type MemoryWebhookDedupStore struct {
+	mu        sync.Mutex
+	keys      map[string]time.Time
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+// NewMemoryWebhookDedupStore - creates an empty in-memory dedup store
+func NewMemoryWebhookDedupStore() *MemoryWebhookDedupStore {
+	return &MemoryWebhookDedupStore{
+		keys: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Add - records key for ttl and reports whether it was not already present
+func (s *MemoryWebhookDedupStore) Add(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastPrune) > time.Minute {
+		for k, expires := range s.keys {
+			if now.After(expires) {
+				delete(s.keys, k)
+			}
+		}
+		s.lastPrune = now
+	}
+
+	if expires, ok := s.keys[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+	s.keys[key] = now.Add(ttl)
+
+	return true, nil
+}
+
+// Remove - forgets key
+func (s *MemoryWebhookDedupStore) Remove(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, key)
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package mailersend_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mailersend/mailersend-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func webhookRequest(body []byte, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set(mailersend.WebhookSignatureHeader, signature)
+	return req
+}
+
+func freshPayload(eventType string) []byte {
+	return []byte(fmt.Sprintf(`{"type": %q, "created_at": %q, "data": {"id": "activity-id"}}`,
+		eventType, time.Now().UTC().Format(time.RFC3339Nano)))
+}
+
+func serveWebhook(h http.Handler, body []byte, signature string) int {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, webhookRequest(body, signature))
+	return rec.Code
+}
+
+func TestWebhookHandlerDispatchesEvents(t *testing.T) {
+	handler := mailersend.NewWebhookHandler(webhookSecret)
+
+	var delivered, all int
+	handler.OnDelivered(func(ctx context.Context, event *mailersend.ActivityEvent) error {
+		delivered++
+		assert.Equal(t, "activity-id", event.Data.ID)
+		return nil
+	})
+	handler.OnAny(func(ctx context.Context, event mailersend.WebhookEvent) error {
+		all++
+		return nil
+	})
+
+	body := freshPayload(mailersend.EventActivityDelivered)
+	code := serveWebhook(handler, body, mailersend.ComputeWebhookSignature(webhookSecret, body))
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 1, all)
+}
+
+func TestWebhookHandlerRejectsInvalidRequests(t *testing.T) {
+	handler := mailersend.NewWebhookHandler(webhookSecret)
+	body := freshPayload(mailersend.EventActivityDelivered)
+
+	assert.Equal(t, http.StatusUnauthorized, serveWebhook(handler, body, "invalid"))
+
+	invalid := []byte(`{"type": `)
+	assert.Equal(t, http.StatusBadRequest, serveWebhook(handler, invalid, mailersend.ComputeWebhookSignature(webhookSecret, invalid)))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestWebhookHandlerRejectsStaleDeliveries(t *testing.T) {
+	handler := mailersend.NewWebhookHandler(webhookSecret)
+	handler.SetTolerance(time.Minute)
+
+	body := []byte(fmt.Sprintf(`{"type": "activity.sent", "created_at": %q, "data": {}}`,
+		time.Now().Add(-time.Hour).UTC().Format(time.RFC3339Nano)))
+
+	assert.Equal(t, http.StatusBadRequest, serveWebhook(handler, body, mailersend.ComputeWebhookSignature(webhookSecret, body)))
+
+	handler.SetTolerance(0)
+	assert.Equal(t, http.StatusOK, serveWebhook(handler, body, mailersend.ComputeWebhookSignature(webhookSecret, body)))
+}
+
+func TestWebhookHandlerIgnoresDuplicates(t *testing.T) {
+	handler := mailersend.NewWebhookHandler(webhookSecret)
+
+	var calls int
+	handler.OnHardBounced(func(ctx context.Context, event *mailersend.ActivityEvent) error {
+		calls++
+		return nil
+	})
+
+	body := freshPayload(mailersend.EventActivityHardBounced)
+	signature := mailersend.ComputeWebhookSignature(webhookSecret, body)
+
+	assert.Equal(t, http.StatusOK, serveWebhook(handler, body, signature))
+	assert.Equal(t, http.StatusOK, serveWebhook(handler, body, signature))
+	assert.Equal(t, 1, calls)
+}
+
+func TestWebhookHandlerRetriesFailedCallbacks(t *testing.T) {
+	handler := mailersend.NewWebhookHandler(webhookSecret)
+
+	var calls int
+	handler.OnSpamComplaint(func(ctx context.Context, event *mailersend.ActivityEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	body := freshPayload(mailersend.EventActivitySpamComplaint)
+	signature := mailersend.ComputeWebhookSignature(webhookSecret, body)
+
+	assert.Equal(t, http.StatusInternalServerError, serveWebhook(handler, body, signature))
+	assert.Equal(t, http.StatusOK, serveWebhook(handler, body, signature))
+	assert.Equal(t, 2, calls)
+}
+
+func TestWebhookHandlerWithTestServer(t *testing.T) {
+	handler := mailersend.NewWebhookHandler(webhookSecret)
+
+	received := make(chan string, 1)
+	handler.OnBulkEmailCompleted(func(ctx context.Context, event *mailersend.BulkEmailCompletedEvent) error {
+		received <- event.Data.ID
+		return nil
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := []byte(`{"type": "bulk_email.completed", "data": {"id": "bulk-id"}}`)
+	req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(body))
+	req.Header.Set(mailersend.WebhookSignatureHeader, mailersend.ComputeWebhookSignature(webhookSecret, body))
+
+	res, err := server.Client().Do(req)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "bulk-id", <-received)
+}
+
+func TestMemoryWebhookDedupStore(t *testing.T) {
+	store := mailersend.NewMemoryWebhookDedupStore()
+	ctx := context.Background()
+
+	added, err := store.Add(ctx, "key", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, added)
+
+	added, _ = store.Add(ctx, "key", time.Minute)
+	assert.False(t, added)
+
+	assert.NoError(t, store.Remove(ctx, "key"))
+	added, _ = store.Add(ctx, "key", time.Minute)
+	assert.True(t, added)
+}
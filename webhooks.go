@@ -78,7 +78,7 @@ func (s *webhookService) List(ctx context.Context, options *ListWebhookOptions)
 	}
 
 	root := new(WebhookRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Webhook.List", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -95,7 +95,7 @@ func (s *webhookService) Get(ctx context.Context, webhookID string) (*SingleWebh
 	}
 
 	root := new(SingleWebhookRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Webhook.Get", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -113,7 +113,7 @@ func (s *webhookService) Create(ctx context.Context, options *CreateWebhookOptio
 	}
 
 	root := new(SingleWebhookRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Webhook.Create", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -130,7 +130,7 @@ func (s *webhookService) Update(ctx context.Context, options *UpdateWebhookOptio
 	}
 
 	root := new(SingleWebhookRoot)
-	res, err := s.client.do(ctx, req, root)
+	res, err := s.client.do(ctx, "Webhook.Update", req, root)
 	if err != nil {
 		return nil, res, err
 	}
@@ -146,5 +146,5 @@ func (s *webhookService) Delete(ctx context.Context, webhookID string) (*Respons
 		return nil, err
 	}
 
-	return s.client.do(ctx, req, nil)
+	return s.client.do(ctx, "Webhook.Delete", req, nil)
 }